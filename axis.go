@@ -1,5 +1,10 @@
 package spider
 
+import (
+	"fmt"
+	"math"
+)
+
 func DefaultAxisLabelStyle() Font {
 	return Font{
 		Size:  DefaultAxisLabelFontSize,
@@ -15,21 +20,28 @@ func DefaultTickLabelStyle() Font {
 }
 
 type AxisOptions struct {
-	LineThickness          float64 `json:"line_thickness" yaml:"line_thickness"`                       // Thickness of the axis line in millimeters
-	LineColor              Color   `json:"line_color" yaml:"line_color"`                               // Color of the axis line
-	LabelOffset            float64 `json:"label_offset" yaml:"label_offset"`                           // Offset for the axis label in millimeters
-	LabelStyle             Font    `json:"label_style" yaml:"label_style"`                             // Style for the axis label
-	TickLabelStyle         Font    `json:"tick_label_style" yaml:"tick_label_style"`                   // Style for the tick labels
-	MajorTicks             int     `json:"major_ticks" yaml:"major_ticks"`                             // Number of major ticks
-	MinorTicks             int     `json:"minor_ticks" yaml:"minor_ticks"`                             // Number of minor ticks
-	MajorTickLength        float64 `json:"major_tick_length" yaml:"major_tick_length"`                 // Length of the major ticks in millimeters
-	MinorTickLength        float64 `json:"minor_tick_length" yaml:"minor_tick_length"`                 // Length of the minor ticks in millimeters
-	MajorTickLineThickness float64 `json:"major_tick_line_thickness" yaml:"major_tick_line_thickness"` // Thickness of the major tick lines in millimeters
-	MinorTickLineThickness float64 `json:"minor_tick_line_thickness" yaml:"minor_tick_line_thickness"` // Thickness of the minor tick lines in millimeters
-	ShowName               bool    `json:"show_name" yaml:"show_name"`                                 // Whether to show the axis name
-	ShowAxis               bool    `json:"show_axis" yaml:"show_axis"`                                 // Whether to show the axis
-	ShowTicks              bool    `json:"show_ticks" yaml:"show_ticks"`                               // Whether to show the ticks
-	ShowTickLabels         bool    `json:"show_tick_labels" yaml:"show_tick_labels"`                   // Whether to show the tick labels
+	LineThickness          float64       `json:"line_thickness" yaml:"line_thickness"`                       // Thickness of the axis line in millimeters
+	LineColor              Color         `json:"line_color" yaml:"line_color"`                               // Color of the axis line
+	LabelOffset            float64       `json:"label_offset" yaml:"label_offset"`                           // Offset for the axis label in millimeters
+	LabelStyle             Font          `json:"label_style" yaml:"label_style"`                             // Style for the axis label
+	LabelMode              AxisLabelMode `json:"label_mode" yaml:"label_mode"`                               // Orientation of the axis label (tangential, radial or horizontal)
+	LabelMaxWidth          float64       `json:"label_max_width" yaml:"label_max_width"`                     // Width at which the axis label wraps in millimeters (0 disables wrapping)
+	TickLabelStyle         Font          `json:"tick_label_style" yaml:"tick_label_style"`                   // Style for the tick labels
+	TickLabelFormat        ValueFormat   `json:"tick_label_format" yaml:"tick_label_format"`                 // Format of the tick labels and value labels
+	TickMode               TickMode      `json:"tick_mode" yaml:"tick_mode"`                                 // How major ticks are placed on linear axes (nice or fixed)
+	NiceRange              bool          `json:"nice_range" yaml:"nice_range"`                               // Whether to extend auto-calculated min/max to the next nice tick
+	MajorTicks             int           `json:"major_ticks" yaml:"major_ticks"`                             // Number of major ticks (target number when tick_mode is nice)
+	MinorTicks             int           `json:"minor_ticks" yaml:"minor_ticks"`                             // Number of minor ticks
+	MajorTickLength        float64       `json:"major_tick_length" yaml:"major_tick_length"`                 // Length of the major ticks in millimeters
+	MinorTickLength        float64       `json:"minor_tick_length" yaml:"minor_tick_length"`                 // Length of the minor ticks in millimeters
+	MajorTickLineThickness float64       `json:"major_tick_line_thickness" yaml:"major_tick_line_thickness"` // Thickness of the major tick lines in millimeters
+	MinorTickLineThickness float64       `json:"minor_tick_line_thickness" yaml:"minor_tick_line_thickness"` // Thickness of the minor tick lines in millimeters
+	ShowName               bool          `json:"show_name" yaml:"show_name"`                                 // Whether to show the axis name
+	ShowAxis               bool          `json:"show_axis" yaml:"show_axis"`                                 // Whether to show the axis
+	ShowTicks              bool          `json:"show_ticks" yaml:"show_ticks"`                               // Whether to show the ticks
+	ShowTickLabels         bool          `json:"show_tick_labels" yaml:"show_tick_labels"`                   // Whether to show the tick labels
+	ShowInvertedMarker     bool          `json:"show_inverted_marker" yaml:"show_inverted_marker"`           // Whether to mark inverted axes in the axis label
+	InvertedMarker         string        `json:"inverted_marker" yaml:"inverted_marker"`                     // Text appended to the label of inverted axes
 }
 
 // DefaultAxisOptions returns a default axis options
@@ -40,7 +52,12 @@ func DefaultAxisOptions() AxisOptions {
 		LineColor:              Color("#000000"),
 		LabelOffset:            DefaultLabelOffset,
 		LabelStyle:             DefaultAxisLabelStyle(),
+		LabelMode:              DefaultAxisLabelMode,
+		LabelMaxWidth:          0,
 		TickLabelStyle:         DefaultTickLabelStyle(),
+		TickLabelFormat:        DefaultValueFormat(),
+		TickMode:               DefaultTickMode,
+		NiceRange:              true,
 		MajorTicks:             DefaultMajorTickCount,
 		MinorTicks:             DefaultMinorTickCount,
 		MajorTickLength:        DefaultMajorTickLength,
@@ -51,13 +68,206 @@ func DefaultAxisOptions() AxisOptions {
 		ShowAxis:               true,
 		ShowTicks:              true,
 		ShowTickLabels:         true,
+		ShowInvertedMarker:     false,
+		InvertedMarker:         DefaultInvertedMarker,
 	}
 }
 
+// AxisOptionsOverride holds per-axis overrides of the global AxisOptions.
+// Nil fields inherit the value from ChartOptions.AxisOptions.
+type AxisOptionsOverride struct {
+	LineThickness          *float64       `json:"line_thickness,omitempty" yaml:"line_thickness,omitempty"`                       // Thickness of the axis line in millimeters
+	LineColor              *Color         `json:"line_color,omitempty" yaml:"line_color,omitempty"`                               // Color of the axis line
+	LabelOffset            *float64       `json:"label_offset,omitempty" yaml:"label_offset,omitempty"`                           // Offset for the axis label in millimeters
+	LabelStyle             *Font          `json:"label_style,omitempty" yaml:"label_style,omitempty"`                             // Style for the axis label, merged field by field
+	LabelMode              *AxisLabelMode `json:"label_mode,omitempty" yaml:"label_mode,omitempty"`                               // Orientation of the axis label
+	LabelMaxWidth          *float64       `json:"label_max_width,omitempty" yaml:"label_max_width,omitempty"`                     // Width at which the axis label wraps in millimeters
+	TickLabelStyle         *Font          `json:"tick_label_style,omitempty" yaml:"tick_label_style,omitempty"`                   // Style for the tick labels, merged field by field
+	TickLabelFormat        *ValueFormat   `json:"tick_label_format,omitempty" yaml:"tick_label_format,omitempty"`                 // Format of the tick labels, merged field by field
+	TickMode               *TickMode      `json:"tick_mode,omitempty" yaml:"tick_mode,omitempty"`                                 // How major ticks are placed on linear axes
+	NiceRange              *bool          `json:"nice_range,omitempty" yaml:"nice_range,omitempty"`                               // Whether to extend auto-calculated min/max to the next nice tick
+	MajorTicks             *int           `json:"major_ticks,omitempty" yaml:"major_ticks,omitempty"`                             // Number of major ticks
+	MinorTicks             *int           `json:"minor_ticks,omitempty" yaml:"minor_ticks,omitempty"`                             // Number of minor ticks
+	MajorTickLength        *float64       `json:"major_tick_length,omitempty" yaml:"major_tick_length,omitempty"`                 // Length of the major ticks in millimeters
+	MinorTickLength        *float64       `json:"minor_tick_length,omitempty" yaml:"minor_tick_length,omitempty"`                 // Length of the minor ticks in millimeters
+	MajorTickLineThickness *float64       `json:"major_tick_line_thickness,omitempty" yaml:"major_tick_line_thickness,omitempty"` // Thickness of the major tick lines in millimeters
+	MinorTickLineThickness *float64       `json:"minor_tick_line_thickness,omitempty" yaml:"minor_tick_line_thickness,omitempty"` // Thickness of the minor tick lines in millimeters
+	ShowName               *bool          `json:"show_name,omitempty" yaml:"show_name,omitempty"`                                 // Whether to show the axis name
+	ShowAxis               *bool          `json:"show_axis,omitempty" yaml:"show_axis,omitempty"`                                 // Whether to show the axis
+	ShowTicks              *bool          `json:"show_ticks,omitempty" yaml:"show_ticks,omitempty"`                               // Whether to show the ticks
+	ShowTickLabels         *bool          `json:"show_tick_labels,omitempty" yaml:"show_tick_labels,omitempty"`                   // Whether to show the tick labels
+	ShowInvertedMarker     *bool          `json:"show_inverted_marker,omitempty" yaml:"show_inverted_marker,omitempty"`           // Whether to mark inverted axes in the axis label
+	InvertedMarker         *string        `json:"inverted_marker,omitempty" yaml:"inverted_marker,omitempty"`                     // Text appended to the label of inverted axes
+}
+
+// apply returns a copy of base with the non-nil override fields applied
+func (o *AxisOptionsOverride) apply(base AxisOptions) AxisOptions {
+	if o == nil {
+		return base
+	}
+	opts := base
+	if o.LineThickness != nil {
+		opts.LineThickness = *o.LineThickness
+	}
+	if o.LineColor != nil {
+		opts.LineColor = *o.LineColor
+	}
+	if o.LabelOffset != nil {
+		opts.LabelOffset = *o.LabelOffset
+	}
+	if o.LabelStyle != nil {
+		opts.LabelStyle = base.LabelStyle.merge(*o.LabelStyle)
+	}
+	if o.LabelMode != nil {
+		opts.LabelMode = *o.LabelMode
+	}
+	if o.LabelMaxWidth != nil {
+		opts.LabelMaxWidth = *o.LabelMaxWidth
+	}
+	if o.TickLabelStyle != nil {
+		opts.TickLabelStyle = base.TickLabelStyle.merge(*o.TickLabelStyle)
+	}
+	if o.TickMode != nil {
+		opts.TickMode = *o.TickMode
+	}
+	if o.NiceRange != nil {
+		opts.NiceRange = *o.NiceRange
+	}
+	if o.TickLabelFormat != nil {
+		opts.TickLabelFormat = base.TickLabelFormat.merge(*o.TickLabelFormat)
+	}
+	if o.MajorTicks != nil {
+		opts.MajorTicks = *o.MajorTicks
+	}
+	if o.MinorTicks != nil {
+		opts.MinorTicks = *o.MinorTicks
+	}
+	if o.MajorTickLength != nil {
+		opts.MajorTickLength = *o.MajorTickLength
+	}
+	if o.MinorTickLength != nil {
+		opts.MinorTickLength = *o.MinorTickLength
+	}
+	if o.MajorTickLineThickness != nil {
+		opts.MajorTickLineThickness = *o.MajorTickLineThickness
+	}
+	if o.MinorTickLineThickness != nil {
+		opts.MinorTickLineThickness = *o.MinorTickLineThickness
+	}
+	if o.ShowName != nil {
+		opts.ShowName = *o.ShowName
+	}
+	if o.ShowAxis != nil {
+		opts.ShowAxis = *o.ShowAxis
+	}
+	if o.ShowTicks != nil {
+		opts.ShowTicks = *o.ShowTicks
+	}
+	if o.ShowTickLabels != nil {
+		opts.ShowTickLabels = *o.ShowTickLabels
+	}
+	if o.ShowInvertedMarker != nil {
+		opts.ShowInvertedMarker = *o.ShowInvertedMarker
+	}
+	if o.InvertedMarker != nil {
+		opts.InvertedMarker = *o.InvertedMarker
+	}
+	return opts
+}
+
+// tickMode returns the overridden tick mode, or an empty string if it is not overridden
+func (o *AxisOptionsOverride) tickMode() TickMode {
+	if o == nil || o.TickMode == nil {
+		return ""
+	}
+	return *o.TickMode
+}
+
+// labelMode returns the overridden label mode, or an empty string if it is not overridden
+func (o *AxisOptionsOverride) labelMode() AxisLabelMode {
+	if o == nil || o.LabelMode == nil {
+		return ""
+	}
+	return *o.LabelMode
+}
+
 // Axis represents a single axis in the spider chart
 type Axis struct {
-	Name string  `json:"name" yaml:"name"`                   // Axis name
-	Max  float64 `json:"max,omitempty" yaml:"max,omitempty"` // Maximum value (zero means auto-calculate)
+	Name    string    `json:"name" yaml:"name"`                             // Axis name, used as the key into series data
+	Label   string    `json:"label,omitempty" yaml:"label,omitempty"`       // Text drawn next to the axis (defaults to the name, may contain line breaks)
+	Unit    string    `json:"unit,omitempty" yaml:"unit,omitempty"`         // Unit drawn under the axis label
+	Min     float64   `json:"min,omitempty" yaml:"min,omitempty"`           // Minimum value at the center of the plot
+	Max     float64   `json:"max,omitempty" yaml:"max,omitempty"`           // Maximum value (zero means auto-calculate)
+	AutoMin bool      `json:"auto_min,omitempty" yaml:"auto_min,omitempty"` // Whether to calculate the minimum from series data
+	Scale   ScaleType `json:"scale,omitempty" yaml:"scale,omitempty"`       // Scale type (linear, log10 or log2, defaults to linear)
+	Invert  bool      `json:"invert,omitempty" yaml:"invert,omitempty"`     // Whether lower values are better (max at the center, min at the rim)
+
+	Options *AxisOptionsOverride `json:"options,omitempty" yaml:"options,omitempty"` // Overrides of the global axis options for this axis
+}
+
+// GetScaleType returns the scale type of the axis, defaulting to linear
+func (a *Axis) GetScaleType() ScaleType {
+	if a.Scale == "" {
+		return ScaleTypeLinear
+	}
+	return a.Scale
+}
+
+// IsLog returns true if the axis uses a logarithmic scale
+func (a *Axis) IsLog() bool {
+	return a.GetScaleType() == ScaleTypeLog10 || a.GetScaleType() == ScaleTypeLog2
+}
+
+// dataRange returns the smallest and largest values for the axis in the series data.
+// Logarithmic axes only consider positive values.
+func (a *Axis) dataRange(seriesData []map[string]float64) (float64, float64, bool) {
+	min := math.Inf(1)
+	max := math.Inf(-1)
+	for _, data := range seriesData {
+		val, ok := data[a.Name]
+		if !ok || (a.IsLog() && val <= 0) {
+			continue
+		}
+		min = math.Min(min, val)
+		max = math.Max(max, val)
+	}
+	return min, max, !math.IsInf(min, 1)
+}
+
+// GetMin returns the minimum value for the axis, calculating it from series data if
+// AutoMin is set. Logarithmic axes calculate the minimum whenever Min is not positive.
+func (a *Axis) GetMin(seriesData []map[string]float64) float64 {
+	if a.IsLog() {
+		if a.Min > 0 && !a.AutoMin {
+			return a.Min
+		}
+		// Round down to the largest power of the base below the smallest value
+		b := axisScale{Type: a.GetScaleType()}.base()
+		max := a.GetMax(seriesData)
+		min, _, ok := a.dataRange(seriesData)
+		if !ok || min >= max {
+			min = max / b
+		}
+		return math.Pow(b, math.Floor(logb(min, b)+tickEpsilon))
+	}
+	if !a.AutoMin {
+		return a.Min
+	}
+	// Auto-calculate min from series data
+	min, max, ok := a.dataRange(seriesData)
+	if !ok {
+		return 0.0 // Default to 0.0 if no data
+	}
+	// Subtract autoscale padding relative to the data range
+	padding := (max - min) * (AutoscaleAxisPaddingFactor - 1)
+	if padding == 0 {
+		padding = math.Abs(min) * (AutoscaleAxisPaddingFactor - 1)
+	}
+	// Don't let padding push an all-positive axis below zero
+	if min >= 0 && min-padding < 0 {
+		return 0.0
+	}
+	return min - padding
 }
 
 // GetMax returns the maximum value for the axis, calculating it from series data if needed
@@ -66,15 +276,141 @@ func (a *Axis) GetMax(seriesData []map[string]float64) float64 {
 		return a.Max
 	}
 	// Auto-calculate max from series data
-	max := 0.0
-	for _, data := range seriesData {
-		if val, ok := data[a.Name]; ok && val > max {
-			max = val
+	_, max, ok := a.dataRange(seriesData)
+	// Logarithmic axes are rounded up to the next power of the base
+	if a.IsLog() {
+		b := axisScale{Type: a.GetScaleType()}.base()
+		if ok {
+			return math.Pow(b, math.Ceil(logb(max, b)-tickEpsilon))
+		}
+		return b // Default to one power above 1 if no data
+	}
+	// Add autoscale padding relative to the axis minimum
+	min := a.GetMin(seriesData)
+	if ok && max > min {
+		return max + (max-min)*(AutoscaleAxisPaddingFactor-1)
+	}
+	return min + 1.0 // Default to a unit range if no data
+}
+
+// scale returns the scale used to map data values onto the axis
+func (a *Axis) scale(seriesData []map[string]float64, opts AxisOptions) axisScale {
+	s := axisScale{
+		Type:   a.GetScaleType(),
+		Min:    a.GetMin(seriesData),
+		Max:    a.GetMax(seriesData),
+		Invert: a.Invert,
+	}
+	if s.isLog() || opts.TickMode != TickModeNice {
+		return s
+	}
+	s.Step = niceStep(s.Max-s.Min, opts.MajorTicks+1)
+	if opts.NiceRange && s.Step > 0 {
+		// Extend auto-calculated ends outwards to the next nice tick
+		if a.Max <= 0 {
+			s.Max = math.Ceil(s.Max/s.Step-tickEpsilon) * s.Step
+		}
+		if a.AutoMin {
+			s.Min = math.Floor(s.Min/s.Step+tickEpsilon) * s.Step
+		}
+	}
+	return s
+}
+
+// GetLabel returns the display label of the axis, defaulting to its name
+func (a *Axis) GetLabel() string {
+	if a.Label == "" {
+		return a.Name
+	}
+	return a.Label
+}
+
+// displayLabel returns the label drawn next to the axis, including the inverted marker
+func (a *Axis) displayLabel(opts AxisOptions) string {
+	if a.Invert && opts.ShowInvertedMarker && opts.InvertedMarker != "" {
+		return a.GetLabel() + " " + opts.InvertedMarker
+	}
+	return a.GetLabel()
+}
+
+// validate checks the axis scale and the series values that will be plotted on it
+func (a *Axis) validate(series []Series) error {
+	switch a.GetScaleType() {
+	case ScaleTypeLinear, ScaleTypeLog10, ScaleTypeLog2:
+	default:
+		return &ValidationError{
+			Field:   "axes.scale",
+			Message: fmt.Sprintf("axis %s has unknown scale type: %s", a.Name, a.Scale),
+		}
+	}
+	switch a.Options.tickMode() {
+	case "", TickModeNice, TickModeFixed:
+	default:
+		return &ValidationError{
+			Field:   "axes.options.tick_mode",
+			Message: fmt.Sprintf("axis %s has unknown tick mode: %s", a.Name, *a.Options.TickMode),
+		}
+	}
+	switch a.Options.labelMode() {
+	case "", AxisLabelTangential, AxisLabelRadial, AxisLabelHorizontal:
+	default:
+		return &ValidationError{
+			Field:   "axes.options.label_mode",
+			Message: fmt.Sprintf("axis %s has unknown label mode: %s", a.Name, *a.Options.LabelMode),
+		}
+	}
+	if a.Options != nil && a.Options.LabelMaxWidth != nil && *a.Options.LabelMaxWidth < 0 {
+		return &ValidationError{
+			Field:   "axes.options.label_max_width",
+			Message: fmt.Sprintf("axis %s label_max_width must not be negative", a.Name),
+		}
+	}
+	if a.Options != nil && a.Options.TickLabelFormat != nil {
+		if err := a.Options.TickLabelFormat.validate(); err != nil {
+			return &ValidationError{
+				Field:   "axes.options.tick_label_format",
+				Message: fmt.Sprintf("axis %s: %s", a.Name, err),
+			}
 		}
 	}
-	// Add autoscale padding
-	if max > 0 {
-		return max * AutoscaleAxisPaddingFactor
+	if a.Max > 0 && !a.AutoMin && a.Max <= a.Min {
+		return &ValidationError{
+			Field:   "axes.max",
+			Message: fmt.Sprintf("axis %s max (%g) must be greater than min (%g)", a.Name, a.Max, a.Min),
+		}
+	}
+	if !a.IsLog() {
+		return nil
+	}
+	if a.Max < 0 || a.Min < 0 {
+		return &ValidationError{
+			Field:   "axes.max",
+			Message: fmt.Sprintf("axis %s uses a logarithmic scale and requires a positive min and max", a.Name),
+		}
+	}
+	for _, s := range series {
+		if val, ok := s.values[a.Name]; ok && val <= 0 {
+			return &ValidationError{
+				Field:   "series.data",
+				Message: fmt.Sprintf("series %s has non-positive value %g on logarithmic axis %s", s.Name, val, a.Name),
+			}
+		}
+		if r, ok := s.ranges[a.Name]; ok {
+			if low, _ := r.Bounds(s.values[a.Name]); low <= 0 {
+				return &ValidationError{
+					Field:   "series.ranges",
+					Message: fmt.Sprintf("series %s has a range with non-positive low bound %g on logarithmic axis %s", s.Name, low, a.Name),
+				}
+			}
+		}
+		for _, snapshot := range s.History {
+			if val, ok := snapshot.Data[a.Name]; ok && val <= 0 {
+				return &ValidationError{
+					Field:   "series.history",
+					Message: fmt.Sprintf("series %s snapshot %s has non-positive value %g on logarithmic axis %s", s.Name, snapshot.Time, val, a.Name),
+				}
+			}
+		}
 	}
-	return 1.0 // Default to 1.0 if no data
+	return nil
 }
@@ -0,0 +1,250 @@
+package spider
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+)
+
+// axisNameAttempts is the number of times an axis name is pushed outward to avoid
+// overlapping the names placed before it
+const axisNameAttempts = 8
+
+// axisNameSpacing is the minimum space between two axis names in millimeters
+const axisNameSpacing = 1.0
+
+// textLine is a line of text in a font face
+type textLine struct {
+	face *canvas.FontFace
+	text string
+}
+
+// axisName is the name of an axis: its label lines followed by its unit line, placed
+// at the end of the axis
+type axisName struct {
+	axis   int          // Index of the axis
+	dir    canvas.Point // Unit vector along the axis
+	opts   AxisOptions  // Options of the axis
+	lines  []textLine   // Label lines followed by the unit line
+	width  float64      // Width of the widest line in millimeters
+	height float64      // Height of all lines in millimeters
+	nudge  float64      // Distance the name is pushed outward to clear other names in millimeters
+}
+
+// axisNames returns the names of the axes that show one, not yet placed
+func (c *Chart) axisNames() []axisName {
+	if !c.Options.ShowAxisNames {
+		return nil
+	}
+	var names []axisName
+	for j := range c.Data.Axes {
+		axis := &c.Data.Axes[j]
+		opts := c.axisOptions(axis)
+		if !opts.ShowName {
+			continue
+		}
+		lines := c.axisLabelLines(axis, opts)
+		width, height := axisLabelSize(lines)
+		theta := c.axisAngle(j)
+		names = append(names, axisName{
+			axis:   j,
+			dir:    canvas.Point{X: math.Cos(theta), Y: math.Sin(theta)},
+			opts:   opts,
+			lines:  lines,
+			width:  width,
+			height: height,
+		})
+	}
+	return names
+}
+
+// axisLabelLines returns the lines of the label of an axis, wrapped to the maximum
+// label width, followed by its unit
+func (c *Chart) axisLabelLines(axis *Axis, opts AxisOptions) []textLine {
+	var lines []textLine
+	labelFace := c.axisFont(axis, "axis_label")
+	for _, line := range strings.Split(axis.displayLabel(opts), "\n") {
+		for _, wrapped := range wrapText(labelFace, line, opts.LabelMaxWidth) {
+			lines = append(lines, textLine{labelFace, wrapped})
+		}
+	}
+	if axis.Unit != "" {
+		lines = append(lines, textLine{c.axisFont(axis, "tick_label"), axis.Unit})
+	}
+	return lines
+}
+
+// axisLabelSize returns the width and height of a block of label lines
+func axisLabelSize(lines []textLine) (float64, float64) {
+	width, height := 0.0, 0.0
+	for _, line := range lines {
+		width = math.Max(width, line.face.TextWidth(line.text))
+		height += line.face.LineHeight()
+	}
+	return width, height
+}
+
+// wrapText breaks text at spaces into lines that are at most maxWidth wide, except
+// for words that are wider on their own. A maxWidth of 0 disables wrapping.
+func wrapText(face *canvas.FontFace, text string, maxWidth float64) []string {
+	words := strings.Fields(text)
+	if maxWidth <= 0 || len(words) == 0 {
+		return []string{text}
+	}
+	var lines []string
+	line := words[0]
+	for _, word := range words[1:] {
+		if face.TextWidth(line+" "+word) <= maxWidth {
+			line += " " + word
+			continue
+		}
+		lines = append(lines, line)
+		line = word
+	}
+	return append(lines, line)
+}
+
+// start returns the distance from the plot center to the near edge of the name at
+// plot radius r
+func (n *axisName) start(r float64) float64 {
+	return r + n.opts.LabelOffset + n.nudge
+}
+
+// edge returns the distance from the center of a horizontal name to its edge along
+// the axis
+func (n *axisName) edge() float64 {
+	return math.Min(n.width/2/math.Max(math.Abs(n.dir.X), 1e-9), n.height/2/math.Max(math.Abs(n.dir.Y), 1e-9))
+}
+
+// corners returns the corners of the name in order around it, relative to the plot
+// center at plot radius r
+func (n *axisName) corners(r float64) [4]canvas.Point {
+	d := n.start(r)
+	var length, half float64
+	switch n.opts.LabelMode {
+	case AxisLabelHorizontal:
+		center := n.dir.Mul(d + n.edge())
+		x0, y0 := center.X-n.width/2, center.Y-n.height/2
+		x1, y1 := center.X+n.width/2, center.Y+n.height/2
+		return [4]canvas.Point{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+	case AxisLabelRadial:
+		length, half = n.width, n.height/2
+	default:
+		length, half = n.height, n.width/2
+	}
+	side := canvas.Point{X: -n.dir.Y, Y: n.dir.X}.Mul(half)
+	near, far := n.dir.Mul(d), n.dir.Mul(d+length)
+	return [4]canvas.Point{near.Sub(side), near.Add(side), far.Add(side), far.Sub(side)}
+}
+
+// placeAxisNames places the names at plot radius r. Each name is pushed outward, one
+// label line at a time, while it overlaps a name placed before it. A name that no
+// push clears stays at the end of its axis.
+func placeAxisNames(names []axisName, r float64) {
+	placed := make([][4]canvas.Point, 0, len(names))
+	for k := range names {
+		name := &names[k]
+		name.nudge, _ = nudgeOutward(name.lines[0].face.LineHeight(), axisNameAttempts, func(nudge float64) bool {
+			name.nudge = nudge
+			corners := name.corners(r)
+			return slices.ContainsFunc(placed, func(other [4]canvas.Point) bool {
+				return quadsOverlap(corners, other, axisNameSpacing)
+			})
+		})
+		placed = append(placed, name.corners(r))
+	}
+}
+
+// quadsOverlap returns true if two convex quadrilaterals overlap or are closer than
+// the given spacing, that is if no edge of either separates them by the spacing
+func quadsOverlap(a, b [4]canvas.Point, spacing float64) bool {
+	project := func(q [4]canvas.Point, axis canvas.Point) (float64, float64) {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, p := range q {
+			d := p.Dot(axis)
+			lo, hi = math.Min(lo, d), math.Max(hi, d)
+		}
+		return lo, hi
+	}
+	for _, q := range [][4]canvas.Point{a, b} {
+		for i := range q {
+			edge := q[(i+1)%len(q)].Sub(q[i])
+			normal := canvas.Point{X: -edge.Y, Y: edge.X}.Norm(1)
+			loA, hiA := project(a, normal)
+			loB, hiB := project(b, normal)
+			if hiA+spacing <= loB || hiB+spacing <= loA {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// drawAxisNames draws the axis names at the end of their axes, with a leader line
+// from the axis to every name that was pushed outward
+func (c *Chart) drawAxisNames(ctx *canvas.Context) {
+	names := c.axisNames()
+	radius := c.Radius()
+	placeAxisNames(names, radius)
+	center := c.plotRect.Center()
+	for _, name := range names {
+		if name.nudge > 0 {
+			from := center.Add(name.dir.Mul(radius + name.opts.LabelOffset))
+			to := center.Add(name.dir.Mul(name.start(radius)))
+			ctx.SetStrokeColor(name.opts.LineColor.ToCanvasColor())
+			ctx.SetStrokeWidth(DefaultLeaderLineThickness)
+			ctx.MoveTo(from.X, from.Y)
+			ctx.LineTo(to.X, to.Y)
+			ctx.Stroke()
+		}
+		drawAxisName(ctx, center, name, radius)
+	}
+}
+
+// drawAxisName draws an axis name placed at plot radius r around the plot center
+func drawAxisName(ctx *canvas.Context, center canvas.Point, name axisName, r float64) {
+	theta := math.Atan2(name.dir.Y, name.dir.X) * 180 / math.Pi
+	p := center.Add(name.dir.Mul(name.start(r)))
+	ctx.Push()
+	switch name.opts.LabelMode {
+	case AxisLabelHorizontal:
+		p = center.Add(name.dir.Mul(name.start(r) + name.edge()))
+		ctx.Translate(p.X, p.Y)
+		drawTextLines(ctx, name.lines, name.height/2, canvas.Center)
+	case AxisLabelRadial:
+		// The name reads away from the plot on the right and towards it on the left
+		ctx.Translate(p.X, p.Y)
+		if name.dir.X > -1e-9 {
+			ctx.Rotate(theta)
+			drawTextLines(ctx, name.lines, name.height/2, canvas.Left)
+		} else {
+			ctx.Rotate(theta + 180)
+			drawTextLines(ctx, name.lines, name.height/2, canvas.Right)
+		}
+	default:
+		// The name is turned to stay upright, and its lines are stacked away from the
+		// plot: upwards in the top half and downwards in the bottom half
+		ctx.Translate(p.X, p.Y)
+		if name.dir.Y > -1e-9 {
+			ctx.Rotate(theta - 90)
+			drawTextLines(ctx, name.lines, name.height, canvas.Center)
+		} else {
+			ctx.Rotate(theta + 90)
+			drawTextLines(ctx, name.lines, 0, canvas.Center)
+		}
+	}
+	ctx.Pop()
+}
+
+// drawTextLines draws lines of text below each other, with the top of the first line
+// at y = top
+func drawTextLines(ctx *canvas.Context, lines []textLine, top float64, align canvas.TextAlign) {
+	y := top
+	for _, line := range lines {
+		metrics := line.face.Metrics()
+		ctx.DrawText(0, y-metrics.Ascent, canvas.NewTextLine(line.face, line.text, align))
+		y -= line.face.LineHeight()
+	}
+}
@@ -9,32 +9,41 @@ import (
 
 // ChartOptions represents options for the overall chart
 type ChartOptions struct {
-	Width            float64       `json:"width" yaml:"width"`                                       // Chart width
-	Height           float64       `json:"height" yaml:"height"`                                     // Chart height
-	Background       Color         `json:"background,omitempty" yaml:"background,omitempty"`         // Background color
-	Foreground       Color         `json:"foreground,omitempty" yaml:"foreground,omitempty"`         // Foreground color
-	Title            string        `json:"title,omitempty" yaml:"title,omitempty"`                   // Chart title
-	TitleStyle       Font          `json:"title_style,omitempty" yaml:"title_style,omitempty"`       // Title font style
-	TitleMargin      float64       `json:"title_margin" yaml:"title_margin"`                         // Title margin in millimeters
-	Subtitle         string        `json:"subtitle,omitempty" yaml:"subtitle,omitempty"`             // Chart subtitle
-	SubtitleStyle    Font          `json:"subtitle_style,omitempty" yaml:"subtitle_style,omitempty"` // Subtitle font style
-	SubtitleMargin   float64       `json:"subtitle_margin" yaml:"subtitle_margin"`                   // Subtitle margin in millimeters
-	PlotOptions      PlotOptions   `json:"plot_options" yaml:"plot_options"`                         // Plot options
-	AxisOptions      AxisOptions   `json:"axis_options" yaml:"axis_options"`                         // Axis options
-	SeriesOptions    SeriesOptions `json:"series_options" yaml:"series_options"`                     // Series options
-	LegendOptions    LegendOptions `json:"legend_options" yaml:"legend_options"`                     // Legend options
-	Colors           []Color       `json:"colors" yaml:"colors"`                                     // Colors for the series
-	PointMarkers     []PointShape  `json:"point_markers" yaml:"point_markers"`                       // Point markers for the series
-	PageMargin       float64       `json:"page_margin" yaml:"page_margin"`                           // Page margin in millimeters
-	DefaultFontName  string        `json:"default_font_name" yaml:"default_font_name"`               // Default font name
-	DefaultFontPath  string        `json:"default_font_path" yaml:"default_font_path"`               // Default font path
-	ShowTitle        bool          `json:"show_title" yaml:"show_title"`                             // Whether to show the title
-	ShowSubtitle     bool          `json:"show_subtitle" yaml:"show_subtitle"`                       // Whether to show the subtitle
-	ShowLegend       bool          `json:"show_legend" yaml:"show_legend"`                           // Whether to show the legend
-	ShowAxisNames    bool          `json:"show_axis_labels" yaml:"show_axis_labels"`                 // Whether to show the axis labels
-	ShowTicks        bool          `json:"show_ticks" yaml:"show_ticks"`                             // Whether to show the ticks
-	ShowTickLabels   bool          `json:"show_tick_labels" yaml:"show_tick_labels"`                 // Whether to show the tick labels
-	ShowPointMarkers bool          `json:"show_point_markers" yaml:"show_point_markers"`             // Whether to show the point markers
+	Width            float64           `json:"width" yaml:"width"`                                       // Chart width
+	Height           float64           `json:"height" yaml:"height"`                                     // Chart height
+	Background       Color             `json:"background,omitempty" yaml:"background,omitempty"`         // Background color
+	Foreground       Color             `json:"foreground,omitempty" yaml:"foreground,omitempty"`         // Foreground color
+	Title            string            `json:"title,omitempty" yaml:"title,omitempty"`                   // Chart title
+	TitleStyle       Font              `json:"title_style,omitempty" yaml:"title_style,omitempty"`       // Title font style
+	TitleMargin      float64           `json:"title_margin" yaml:"title_margin"`                         // Title margin in millimeters
+	Subtitle         string            `json:"subtitle,omitempty" yaml:"subtitle,omitempty"`             // Chart subtitle
+	SubtitleStyle    Font              `json:"subtitle_style,omitempty" yaml:"subtitle_style,omitempty"` // Subtitle font style
+	SubtitleMargin   float64           `json:"subtitle_margin" yaml:"subtitle_margin"`                   // Subtitle margin in millimeters
+	PlotOptions      PlotOptions       `json:"plot_options" yaml:"plot_options"`                         // Plot options
+	AxisOptions      AxisOptions       `json:"axis_options" yaml:"axis_options"`                         // Axis options
+	SeriesOptions    SeriesOptions     `json:"series_options" yaml:"series_options"`                     // Series options
+	LegendOptions    LegendOptions     `json:"legend_options" yaml:"legend_options"`                     // Legend options
+	Colors           []Color           `json:"colors" yaml:"colors"`                                     // Colors for the series
+	PointMarkers     []PointShape      `json:"point_markers" yaml:"point_markers"`                       // Point markers for the series
+	MissingValues    MissingValueMode  `json:"missing_values" yaml:"missing_values"`                     // How series without a value for an axis are handled
+	ValueLabels      ValueLabelOptions `json:"value_labels" yaml:"value_labels"`                         // Labels drawn next to the series values
+	Reference        ReferenceOptions  `json:"reference" yaml:"reference"`                               // Comparison against a reference series
+	History          HistoryOptions    `json:"history" yaml:"history"`                                   // How the history snapshots of series are drawn
+	Facets           FacetOptions      `json:"facets" yaml:"facets"`                                     // Small multiples drawn as a grid of panels
+	Table            TableOptions      `json:"table" yaml:"table"`                                       // Table of the series values drawn next to the plot
+	Highlight        []string          `json:"highlight,omitempty" yaml:"highlight,omitempty"`           // Names of the highlighted series, all other series are dimmed
+	DimOpacity       float64           `json:"dim_opacity" yaml:"dim_opacity"`                           // Opacity of the series dimmed by the highlight
+	DimDesaturation  float64           `json:"dim_desaturation" yaml:"dim_desaturation"`                 // Fraction by which the colors of dimmed series are mixed towards gray
+	PageMargin       float64           `json:"page_margin" yaml:"page_margin"`                           // Page margin in millimeters
+	DefaultFontName  string            `json:"default_font_name" yaml:"default_font_name"`               // Default font name
+	DefaultFontPath  string            `json:"default_font_path" yaml:"default_font_path"`               // Default font path
+	ShowTitle        bool              `json:"show_title" yaml:"show_title"`                             // Whether to show the title
+	ShowSubtitle     bool              `json:"show_subtitle" yaml:"show_subtitle"`                       // Whether to show the subtitle
+	ShowLegend       bool              `json:"show_legend" yaml:"show_legend"`                           // Whether to show the legend
+	ShowAxisNames    bool              `json:"show_axis_labels" yaml:"show_axis_labels"`                 // Whether to show the axis labels
+	ShowTicks        bool              `json:"show_ticks" yaml:"show_ticks"`                             // Whether to show the ticks
+	ShowTickLabels   bool              `json:"show_tick_labels" yaml:"show_tick_labels"`                 // Whether to show the tick labels
+	ShowPointMarkers bool              `json:"show_point_markers" yaml:"show_point_markers"`             // Whether to show the point markers
 }
 
 // DefaultChartOptions returns default chart options
@@ -54,6 +63,14 @@ func DefaultChartOptions() ChartOptions {
 		LegendOptions:    DefaultLegendOptions(),
 		Colors:           DefaultSeriesColors,
 		PointMarkers:     DefaultPointMarkers,
+		MissingValues:    DefaultMissingValueMode,
+		ValueLabels:      DefaultValueLabelOptions(),
+		Reference:        DefaultReferenceOptions(),
+		DimOpacity:       DefaultDimOpacity,
+		DimDesaturation:  DefaultDimDesaturation,
+		History:          DefaultHistoryOptions(),
+		Facets:           DefaultFacetOptions(),
+		Table:            DefaultTableOptions(),
 		PageMargin:       DefaultPageMargin,
 		ShowTitle:        true,
 		ShowSubtitle:     true,
@@ -86,6 +103,7 @@ type PlotOptions struct {
 	ConnectType      ConnectType `json:"connect_type" yaml:"connect_type"`           // Connect type for the plot
 	Margin           float64     `json:"margin" yaml:"margin"`                       // Margin of the plot in millimeters
 	Padding          float64     `json:"padding" yaml:"padding"`                     // Padding of the plot in millimeters
+	Grid             GridOptions `json:"grid" yaml:"grid"`                           // Grid ring options
 }
 
 func DefaultPlotOptions() PlotOptions {
@@ -96,6 +114,7 @@ func DefaultPlotOptions() PlotOptions {
 		ConnectType:      DefaultConnectType,
 		Margin:           DefaultPlotMargin,
 		Padding:          DefaultPlotPadding,
+		Grid:             DefaultGridOptions(),
 	}
 }
 
@@ -114,7 +133,9 @@ type Chart struct {
 	plotRect     canvas.Rect                 `json:"-" yaml:"-"`             // Rectangle for the plot
 	pageRect     canvas.Rect                 `json:"-" yaml:"-"`             // Rectangle for the page
 	legendRect   canvas.Rect                 `json:"-" yaml:"-"`             // Rectangle for the legend
+	tableRect    canvas.Rect                 `json:"-" yaml:"-"`             // Rectangle for the table
 	fonts        map[string]*canvas.FontFace `json:"-" yaml:"-"`             // Fonts
+	scaleSeries  []Series                    `json:"-" yaml:"-"`             // Series that determine the axis ranges, if not the chart series
 }
 
 // NewChart creates a new chart with the given options and data
@@ -135,11 +156,136 @@ func NewChart() *Chart {
 
 // Radius returns the radius of the plot area in millimeters
 func (c *Chart) Radius() float64 {
+	if c.plotRect.W() > 0 && c.plotRect.H() > 0 {
+		return math.Min(c.plotRect.W(), c.plotRect.H())/2 - c.Options.PlotOptions.Padding
+	}
 	canvasWidth := c.Width()
 	canvasHeight := c.Height()
 	return c.Options.PlotOptions.Scale*math.Min(canvasWidth, canvasHeight)/2 - c.Options.PlotOptions.Padding
 }
 
+// scaleData returns the data that determines the axis ranges
+func (c *Chart) scaleData() []map[string]float64 {
+	if c.scaleSeries != nil {
+		return getAllSeriesData(c.scaleSeries)
+	}
+	return getAllSeriesData(c.Data.Series)
+}
+
+// axisOptions returns the options for the given axis, with its overrides applied
+func (c *Chart) axisOptions(axis *Axis) AxisOptions {
+	return axis.Options.apply(c.Options.AxisOptions)
+}
+
+// axisScales returns the scale of every axis, in axis order
+func (c *Chart) axisScales() []axisScale {
+	seriesData := c.scaleData()
+	scales := make([]axisScale, len(c.Data.Axes))
+	for i := range c.Data.Axes {
+		scales[i] = c.Data.Axes[i].scale(seriesData, c.axisOptions(&c.Data.Axes[i]))
+	}
+	return scales
+}
+
+// seriesOptions returns the options for the series at the given index, with default
+// colors, point markers and sizes applied where they are not set
+func (c *Chart) seriesOptions(i int) SeriesOptions {
+	seriesOpts := c.paletteOptions(i)
+	if seriesOpts.PointSize == 0 {
+		seriesOpts.PointSize = DefaultPointSize
+	}
+	if seriesOpts.PointLineThickness == 0 {
+		seriesOpts.PointLineThickness = DefaultSeriesLineThickness
+	}
+	if seriesOpts.FillStyle == "" {
+		seriesOpts.FillStyle = FillStyleSolid
+	}
+	if seriesOpts.HatchPattern == "" {
+		seriesOpts.HatchPattern = HatchPatternDiagonal
+	}
+	if seriesOpts.HatchSpacing == 0 {
+		seriesOpts.HatchSpacing = DefaultHatchSpacing
+	}
+	if seriesOpts.HatchThickness == 0 {
+		seriesOpts.HatchThickness = DefaultHatchThickness
+	}
+	if seriesOpts.Curve == "" {
+		seriesOpts.Curve = CurveLinear
+	}
+	if seriesOpts.LineStyle == "" {
+		seriesOpts.LineStyle = LineStyleSolid
+	}
+	if seriesOpts.LineCap == "" {
+		seriesOpts.LineCap = LineCapButt
+	}
+	if seriesOpts.LineJoin == "" {
+		seriesOpts.LineJoin = LineJoinMiter
+	}
+	if c.isReference(i) {
+		// The reference is drawn dashed without fill
+		if seriesOpts.LineStyle == LineStyleSolid && len(seriesOpts.Dashes) == 0 {
+			seriesOpts.LineStyle = LineStyleDashed
+		}
+		seriesOpts.FillOpacity = 0
+		seriesOpts.GradientCenterOpacity = 0
+	}
+	if seriesOpts.OutlineOnly {
+		seriesOpts.FillOpacity = 0
+		seriesOpts.GradientCenterOpacity = 0
+		seriesOpts.PointFillOpacity = 0
+	}
+	if seriesOpts.RangeStyle == "" {
+		seriesOpts.RangeStyle = RangeStyleBand
+	}
+	if seriesOpts.RangeColor == "" {
+		seriesOpts.RangeColor = seriesOpts.LineColor
+	}
+	if seriesOpts.ErrorBarCapWidth == 0 {
+		seriesOpts.ErrorBarCapWidth = DefaultErrorBarCapWidth
+	}
+	if c.isDimmed(i) {
+		seriesOpts = seriesOpts.dimmed(c.Options.DimDesaturation, c.Options.DimOpacity)
+	}
+	return seriesOpts
+}
+
+// paletteOptions returns the options for the series at the given index with the
+// colors and point marker that depend on its position applied where they are not set
+func (c *Chart) paletteOptions(i int) SeriesOptions {
+	seriesOpts := c.Data.Series[i].Options
+	color := c.Options.Colors[i%len(c.Options.Colors)]
+	if seriesOpts.LineColor == "" {
+		seriesOpts.LineColor = color
+	}
+	if seriesOpts.PointStrokeColor == "" {
+		seriesOpts.PointStrokeColor = color
+	}
+	if seriesOpts.PointFillColor == "" {
+		seriesOpts.PointFillColor = color
+	}
+	if seriesOpts.PointShape == "" && seriesOpts.PointPath != "" {
+		seriesOpts.PointShape = PointShapeCustom
+	}
+	if seriesOpts.PointShape == "" {
+		seriesOpts.PointShape = c.Options.PointMarkers[i%len(c.Options.PointMarkers)]
+	}
+	return seriesOpts
+}
+
+// axisFont returns the font face of the given kind ("axis_label" or "tick_label")
+// for an axis, falling back to the global font when the axis has no override
+func (c *Chart) axisFont(axis *Axis, kind string) *canvas.FontFace {
+	if face, ok := c.fonts[axisFontKey(kind, axis.Name)]; ok {
+		return face
+	}
+	return c.fonts[kind]
+}
+
+// axisFontKey returns the key of a per-axis font face in the fonts map
+func axisFontKey(kind string, axisName string) string {
+	return kind + ":" + axisName
+}
+
 func (c *Chart) AddAxis(name string) error {
 	// check that chart axes are not nil
 	if c.Data.Axes == nil {
@@ -208,9 +354,23 @@ func (c *Chart) Height() float64 {
 	return c.Options.Height
 }
 
+// prepareSeries derives the plotted values and ranges of every series from its data,
+// history snapshots and samples
+func (c *Chart) prepareSeries() error {
+	for i := range c.Data.Series {
+		if err := c.Data.Series[i].prepare(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Draw draws the chart to the given canvas context
 func (c *Chart) Draw(ctx *canvas.Context) error {
 	// Validate chart before drawing
+	if err := c.prepareSeries(); err != nil {
+		return err
+	}
 	if err := c.validate(); err != nil {
 		return err
 	}
@@ -221,107 +381,22 @@ func (c *Chart) Draw(ctx *canvas.Context) error {
 	c.drawBackground(ctx)
 	c.drawTitle(ctx)
 	c.drawSubtitle(ctx)
-	c.drawPlotBackground(ctx)
-	c.drawAxes(ctx)
-	c.drawSeries(ctx)
+	if c.Options.Facets.By == FacetNone {
+		c.drawPlot(ctx)
+	} else {
+		c.drawPanels(ctx)
+	}
 	c.drawLegend(ctx)
+	c.drawTable(ctx)
 
 	return nil
 }
 
-func (c *Chart) calcRects() {
-	w := c.Width()
-	h := c.Height()
-
-	plotWidth := w * c.Options.PlotOptions.Scale
-	plotHeight := h * c.Options.PlotOptions.Scale
-	plotX := (w - plotWidth - c.Options.PageMargin) / 2
-	plotY := (h - plotHeight - c.Options.PageMargin) / 2
-	c.pageRect = canvas.Rect{
-		X0: c.Options.PageMargin,
-		Y0: c.Options.PageMargin,
-		X1: w - c.Options.PageMargin,
-		Y1: h - c.Options.PageMargin,
-	}
-	c.plotRect = canvas.Rect{
-		X0: plotX,
-		Y0: plotY,
-		X1: plotX + plotWidth,
-		Y1: plotY + plotHeight,
-	}
-	// legend rect
-	subtitleBottom := c.plotRect.Y1 + c.Options.PlotOptions.Margin
-	switch c.Options.LegendOptions.Placement {
-	case LegendPlacementTop:
-		targetHeight := c.Options.LegendOptions.LegendStyle.Size*mmPerPt*smidge + c.Options.LegendOptions.Padding
-		if targetHeight < c.Options.LegendOptions.MinHeight {
-			targetHeight = c.Options.LegendOptions.MinHeight
-			c.plotRect.Y0 -= targetHeight
-			c.plotRect.Y1 -= targetHeight
-		}
-		c.legendRect = canvas.Rect{ // above plot + plot margin
-			X0: c.Options.PageMargin,
-			Y0: c.plotRect.Y1 + c.Options.PlotOptions.Margin,
-			X1: w - c.Options.PageMargin,
-			Y1: c.plotRect.Y1 + c.Options.PlotOptions.Margin + targetHeight,
-		}
-		subtitleBottom = c.legendRect.Y1 + c.Options.SubtitleMargin
-	case LegendPlacementBottom:
-		// targetHeight := c.plotRect.Y0 - c.Options.PageMargin
-		// if targetHeight < c.Options.LegendOptions.MinHeight {
-		// 	targetHeight = c.Options.LegendOptions.MinHeight
-		// 	c.plotRect.Y0 += targetHeight
-		// 	c.plotRect.Y1 += targetHeight
-		// }
-		c.legendRect = canvas.Rect{
-			X0: c.Options.PageMargin,
-			Y0: c.Options.PageMargin,
-			X1: w - c.Options.PageMargin,
-			Y1: c.plotRect.Y0 - c.Options.PlotOptions.Margin,
-		}
-	case LegendPlacementLeft:
-		targetWidth := c.plotRect.X0 - c.Options.PageMargin
-		if targetWidth < c.Options.LegendOptions.MinWidth {
-			targetWidth = c.Options.LegendOptions.MinWidth
-			c.plotRect.X0 += targetWidth
-			c.plotRect.X1 += targetWidth
-		}
-		c.legendRect = canvas.Rect{
-			X0: c.Options.PageMargin,
-			Y0: c.plotRect.Y0,
-			X1: c.plotRect.X0 - c.Options.PlotOptions.Margin,
-			Y1: c.plotRect.Y1,
-		}
-	case LegendPlacementRight:
-		targetWidth := w - c.plotRect.X1 - c.Options.PageMargin
-		if targetWidth < c.Options.LegendOptions.MinWidth {
-			targetWidth = c.Options.LegendOptions.MinWidth
-			c.plotRect.X1 -= targetWidth
-			c.plotRect.X0 -= targetWidth
-		}
-		c.legendRect = canvas.Rect{
-			X0: c.plotRect.X1 + c.Options.PlotOptions.Margin,
-			Y0: c.plotRect.Y0,
-			X1: w - c.Options.PageMargin,
-			Y1: c.plotRect.Y1,
-		}
-	}
-	// subtitle rect
-	subtitleHeight := c.fonts["subtitle"].LineHeight() * smidge
-	if c.Options.Subtitle == "" {
-		subtitleHeight = 0
-	}
-	c.subtitleRect = canvas.Rect{
-		X0: c.Options.PageMargin,
-		Y0: subtitleBottom,
-		X1: w - c.Options.PageMargin,
-		Y1: subtitleBottom + subtitleHeight,
-	}
-	// title rect
-	c.titleRect = canvas.Rect{
-		X0: c.Options.PageMargin,
-		Y0: c.subtitleRect.Y1 + c.Options.TitleMargin,
-		X1: w - c.Options.PageMargin,
-		Y1: c.pageRect.Y1,
-	}
+// drawPlot draws the grid, axes and series in the plot area
+func (c *Chart) drawPlot(ctx *canvas.Context) {
+	c.drawGrid(ctx)
+	c.drawPlotBackground(ctx)
+	c.drawAxes(ctx)
+	c.drawSeries(ctx)
+	c.drawValueLabels(ctx)
 }
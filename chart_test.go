@@ -0,0 +1,28 @@
+package spider
+
+import (
+	"testing"
+
+	"github.com/tdewolff/canvas"
+)
+
+// seriesConfig returns a YAML chart config with the axes a, b and c, each with a max of
+// 10, and the given series, indented as items of the series list
+func seriesConfig(series string) string {
+	return `
+data:
+  axes:
+    - {name: a, max: 10}
+    - {name: b, max: 10}
+    - {name: c, max: 10}
+  series:
+` + series
+}
+
+// drawChart draws the chart to a canvas of its size
+func drawChart(t *testing.T, c *Chart) {
+	t.Helper()
+	if err := c.Draw(canvas.NewContext(canvas.New(c.Width(), c.Height()))); err != nil {
+		t.Fatal(err)
+	}
+}
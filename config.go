@@ -56,7 +56,10 @@ func NewChartFromJSON(data []byte) (*Chart, error) {
 	// Apply defaults to any nested fields that might still be zero values
 	chart = *applyDefaults(&chart)
 
-	// Validate
+	// Derive the plotted series values from history snapshots and samples, then validate
+	if err := chart.prepareSeries(); err != nil {
+		return nil, fmt.Errorf("chart validation failed: %w", err)
+	}
 	if err := chart.validate(); err != nil {
 		return nil, fmt.Errorf("chart validation failed: %w", err)
 	}
@@ -80,7 +83,10 @@ func NewChartFromYAML(data []byte) (*Chart, error) {
 	// Apply defaults to any nested fields that might still be zero values
 	chart = *applyDefaults(&chart)
 
-	// Validate
+	// Derive the plotted series values from history snapshots and samples, then validate
+	if err := chart.prepareSeries(); err != nil {
+		return nil, fmt.Errorf("chart validation failed: %w", err)
+	}
 	if err := chart.validate(); err != nil {
 		return nil, fmt.Errorf("chart validation failed: %w", err)
 	}
@@ -88,6 +94,30 @@ func NewChartFromYAML(data []byte) (*Chart, error) {
 	return &chart, nil
 }
 
+// UnmarshalJSON decodes a series, starting from the default range opacity so that an
+// explicit range_opacity of 0 is kept
+func (s *Series) UnmarshalJSON(data []byte) error {
+	type plainSeries Series
+	series := plainSeries{Options: SeriesOptions{RangeOpacity: DefaultRangeOpacity}}
+	if err := json.Unmarshal(data, &series); err != nil {
+		return err
+	}
+	*s = Series(series)
+	return nil
+}
+
+// UnmarshalYAML decodes a series, starting from the default range opacity so that an
+// explicit range_opacity of 0 is kept
+func (s *Series) UnmarshalYAML(value *yaml.Node) error {
+	type plainSeries Series
+	series := plainSeries{Options: SeriesOptions{RangeOpacity: DefaultRangeOpacity}}
+	if err := value.Decode(&series); err != nil {
+		return err
+	}
+	*s = Series(series)
+	return nil
+}
+
 func GenerateDefaultConfigJSON(filename string) error {
 	chart := NewChart()
 
@@ -118,6 +148,12 @@ func applyDefaults(chart *Chart) *Chart {
 	if chart.Options.PlotOptions.ConnectType == "" {
 		chart.Options.PlotOptions.ConnectType = DefaultConnectType
 	}
+	if chart.Options.PlotOptions.Grid.Thickness == 0 {
+		chart.Options.PlotOptions.Grid.Thickness = DefaultGridThickness
+	}
+	if chart.Options.PlotOptions.Grid.MinorThickness == 0 {
+		chart.Options.PlotOptions.Grid.MinorThickness = DefaultMinorGridThickness
+	}
 	if chart.Options.TitleStyle.Size == 0 {
 		chart.Options.TitleStyle.Size = DefaultTitleFontSize
 	}
@@ -130,11 +166,77 @@ func applyDefaults(chart *Chart) *Chart {
 	if chart.Options.SubtitleStyle.Color == "" {
 		chart.Options.SubtitleStyle.Color = Color("#000000")
 	}
+	if chart.Options.MissingValues == "" {
+		chart.Options.MissingValues = DefaultMissingValueMode
+	}
+	if chart.Options.ValueLabels.Mode == "" {
+		chart.Options.ValueLabels.Mode = ValueLabelNone
+	}
+	if chart.Options.ValueLabels.Style.Size == 0 {
+		chart.Options.ValueLabels.Style.Size = DefaultValueLabelFontSize
+	}
+	if chart.Options.Reference.ImprovedColor == "" {
+		chart.Options.Reference.ImprovedColor = DefaultImprovedColor
+	}
+	if chart.Options.Reference.RegressedColor == "" {
+		chart.Options.Reference.RegressedColor = DefaultRegressedColor
+	}
+	if chart.Options.Reference.DeltaLabels == "" {
+		chart.Options.Reference.DeltaLabels = DeltaLabelNone
+	}
+	if chart.Options.History.ArrowSize == 0 {
+		chart.Options.History.ArrowSize = DefaultHistoryArrowSize
+	}
+	if chart.Options.Facets.By == "" {
+		chart.Options.Facets.By = FacetNone
+	}
+	if chart.Options.Facets.PanelTitleStyle.Size == 0 {
+		chart.Options.Facets.PanelTitleStyle.Size = DefaultPanelTitleFontSize
+	}
+	if chart.Options.Table.Placement == "" {
+		chart.Options.Table.Placement = TablePlacementNone
+	}
+	if chart.Options.Table.Style.Size == 0 {
+		chart.Options.Table.Style.Size = DefaultTableFontSize
+	}
+	if chart.Options.Table.Style.Color == "" {
+		chart.Options.Table.Style.Color = Color("#000000")
+	}
+	if chart.Options.Table.HeaderStyle.Size == 0 {
+		chart.Options.Table.HeaderStyle.Size = DefaultTableFontSize
+	}
+	if chart.Options.Table.HeaderStyle.Color == "" {
+		chart.Options.Table.HeaderStyle.Color = Color("#000000")
+	}
+	if chart.Options.Table.SwatchSize == 0 {
+		chart.Options.Table.SwatchSize = DefaultTableSwatchSize
+	}
+	if chart.Options.Table.LineColor == "" {
+		chart.Options.Table.LineColor = Color("#000000")
+	}
+	if chart.Options.Table.LineThickness == 0 {
+		chart.Options.Table.LineThickness = DefaultTableLineThickness
+	}
 	if chart.Options.Background == "" {
 		chart.Options.Background = Color("transparent")
 	}
 
 	// Apply axis defaults
+	if chart.Options.AxisOptions.LineColor == "" {
+		chart.Options.AxisOptions.LineColor = Color("#000000")
+	}
+	if chart.Options.AxisOptions.TickMode == "" {
+		chart.Options.AxisOptions.TickMode = DefaultTickMode
+	}
+	if chart.Options.AxisOptions.LabelMode == "" {
+		chart.Options.AxisOptions.LabelMode = DefaultAxisLabelMode
+	}
+	if chart.Options.AxisOptions.TickLabelFormat.Mode == "" {
+		chart.Options.AxisOptions.TickLabelFormat.Mode = FormatModeAuto
+	}
+	if chart.Options.AxisOptions.TickLabelFormat.SignificantDigits == 0 {
+		chart.Options.AxisOptions.TickLabelFormat.SignificantDigits = DefaultSignificantDigits
+	}
 	if chart.Options.AxisOptions.MajorTicks == 0 {
 		chart.Options.AxisOptions.MajorTicks = DefaultMajorTickCount
 	}
@@ -153,6 +255,9 @@ func applyDefaults(chart *Chart) *Chart {
 	if chart.Options.AxisOptions.MinorTickLineThickness == 0 {
 		chart.Options.AxisOptions.MinorTickLineThickness = DefaultMinorTickLineThickness
 	}
+	if chart.Options.AxisOptions.InvertedMarker == "" {
+		chart.Options.AxisOptions.InvertedMarker = DefaultInvertedMarker
+	}
 
 	// Apply series style defaults
 	for i := range chart.Data.Series {
@@ -180,6 +285,15 @@ func applyDefaults(chart *Chart) *Chart {
 	if chart.Options.LegendOptions.Placement == "" {
 		chart.Options.LegendOptions.Placement = LegendPlacementRight
 	}
+	if chart.Options.LegendOptions.TitleStyle.Size == 0 {
+		chart.Options.LegendOptions.TitleStyle.Size = DefaultLegendTitleFontSize
+	}
+	if chart.Options.LegendOptions.TitleStyle.Color == "" {
+		chart.Options.LegendOptions.TitleStyle.Color = Color("#000000")
+	}
+	if chart.Options.LegendOptions.Order == "" {
+		chart.Options.LegendOptions.Order = LegendOrderData
+	}
 
 	return chart
 }
@@ -0,0 +1,42 @@
+package spider
+
+import "testing"
+
+func TestExplicitZeroOpacities(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       string
+		seriesOptions string
+		get           func(c *Chart) float64
+		want          float64
+	}{
+		{"range opacity default", `{}`, `{}`, func(c *Chart) float64 { return c.Data.Series[0].Options.RangeOpacity }, DefaultRangeOpacity},
+		{"range opacity zero", `{}`, `{"range_opacity": 0}`, func(c *Chart) float64 { return c.Data.Series[0].Options.RangeOpacity }, 0},
+		{"delta opacity default", `{"reference": {"series": "s"}}`, `{}`, func(c *Chart) float64 { return c.Options.Reference.DeltaOpacity }, DefaultDeltaOpacity},
+		{"delta opacity zero", `{"reference": {"series": "s", "delta_opacity": 0}}`, `{}`, func(c *Chart) float64 { return c.Options.Reference.DeltaOpacity }, 0},
+		{"history min opacity default", `{"history": {"arrows": true}}`, `{}`, func(c *Chart) float64 { return c.Options.History.MinOpacity }, DefaultHistoryMinOpacity},
+		{"history min opacity zero", `{"history": {"min_opacity": 0}}`, `{}`, func(c *Chart) float64 { return c.Options.History.MinOpacity }, 0},
+	}
+	for _, tt := range tests {
+		// JSON is also valid YAML, so both loaders read the same config
+		config := `{
+			"options": ` + tt.options + `,
+			"data": {
+				"axes": [{"name": "a", "max": 10}, {"name": "b", "max": 10}, {"name": "c", "max": 10}],
+				"series": [{"name": "s", "data": {"a": 1, "b": 2, "c": 3}, "options": ` + tt.seriesOptions + `}]
+			}
+		}`
+		loaders := map[string]func([]byte) (*Chart, error){"JSON": NewChartFromJSON, "YAML": NewChartFromYAML}
+		for format, load := range loaders {
+			t.Run(tt.name+" "+format, func(t *testing.T) {
+				c, err := load([]byte(config))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := tt.get(c); got != tt.want {
+					t.Errorf("got %g, want %g", got, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -18,6 +18,54 @@ const (
 	// DefaultConnectType is the default connection type for the plot shape
 	DefaultConnectType = ConnectTypeCircle
 
+	// DefaultMissingValueMode is the default handling of missing series values
+	DefaultMissingValueMode = MissingValueError
+
+	// DefaultValueLabelFontSize is the default value label font size in points
+	DefaultValueLabelFontSize = 7.0
+
+	// DefaultValueLabelOffset is the default distance between a point and its value label in millimeters
+	DefaultValueLabelOffset = 1.0
+
+	// DefaultImprovedColor is the default color of regions where a series improves on the reference
+	DefaultImprovedColor = Color("#2ca02c")
+
+	// DefaultRegressedColor is the default color of regions where a series regresses from the reference
+	DefaultRegressedColor = Color("#d62728")
+
+	// DefaultDeltaOpacity is the default opacity of the delta shading
+	DefaultDeltaOpacity = 0.3
+
+	// DefaultDimOpacity is the default opacity of series dimmed by the highlight
+	DefaultDimOpacity = 0.3
+
+	// DefaultDimDesaturation is the default fraction by which dimmed series are mixed towards gray
+	DefaultDimDesaturation = 0.8
+
+	// DefaultFacetSpacing is the default space between small multiple panels in millimeters
+	DefaultFacetSpacing = 5.0
+
+	// DefaultPanelTitleFontSize is the default small multiple panel title font size in points
+	DefaultPanelTitleFontSize = 12.0
+
+	// DefaultHistoryMinOpacity is the default opacity of the oldest history snapshot
+	DefaultHistoryMinOpacity = 0.15
+
+	// DefaultHistoryArrowSize is the default length of history arrow heads in millimeters
+	DefaultHistoryArrowSize = 2.0
+
+	// DefaultConfidenceLevel is the default confidence level of bootstrap intervals
+	DefaultConfidenceLevel = 0.95
+
+	// DefaultBootstrapResamples is the default number of bootstrap resamples
+	DefaultBootstrapResamples = 1000
+
+	// DefaultHatchSpacing is the default distance between hatch lines in millimeters
+	DefaultHatchSpacing = 1.5
+
+	// DefaultHatchThickness is the default thickness of hatch lines in millimeters
+	DefaultHatchThickness = 0.2
+
 	// Tau is 2π, used for angle calculations
 	Tau = 2 * math.Pi
 
@@ -51,6 +99,19 @@ const (
 	// DefaultMinorTickCount is the default number of minor ticks per major tick
 	DefaultMinorTickCount = 2
 
+	// DefaultTickMode is the default tick mode for linear axes
+	DefaultTickMode = TickModeNice
+
+	// DefaultAxisLabelMode is the default orientation of the axis names
+	DefaultAxisLabelMode = AxisLabelTangential
+
+	// DefaultLeaderLineThickness is the thickness of the lines that connect nudged
+	// axis names to their axis in millimeters
+	DefaultLeaderLineThickness = 0.2
+
+	// DefaultSignificantDigits is the default number of significant digits in value labels
+	DefaultSignificantDigits = 3
+
 	// DefaultMajorTickSize is the default size of major ticks in millimeters
 	DefaultMajorTickLength = 2.0
 
@@ -69,15 +130,49 @@ const (
 	// DefaultMinorTickLineThickness is the default minor tick line thickness in millimeters
 	DefaultMinorTickLineThickness = 0.25
 
+	// DefaultGridThickness is the default thickness of the major grid rings in millimeters
+	DefaultGridThickness = 0.25
+
+	// DefaultMinorGridThickness is the default thickness of the minor grid rings in millimeters
+	DefaultMinorGridThickness = 0.15
+
+	// DefaultGridBandOpacity is the default opacity of the alternating grid bands
+	DefaultGridBandOpacity = 0.3
+
 	// DefaultSeriesLineThickness is the default line thickness for series in millimeters
 	DefaultSeriesLineThickness = 0.75
 
+	// DefaultRangeOpacity is the default opacity of uncertainty bands
+	DefaultRangeOpacity = 0.25
+
+	// DefaultErrorBarCapWidth is the default width of error bar caps in millimeters
+	DefaultErrorBarCapWidth = 1.5
+
 	// DefaultLegendLineLength is the default line length for legend in millimeters
 	DefaultLegendLineLength = 7.0
 
 	// DefaultLegendLineThickness is the default line thickness for legend in millimeters
 	DefaultLegendLineThickness = 0.6
 
+	// DefaultLegendMaxWidthRatio is the maximum width of a left or right legend
+	// relative to the page width, when no maximum width is set
+	DefaultLegendMaxWidthRatio = 1.0 / 3.0
+
+	// DefaultLegendColumnSpacing is the space between legend columns in millimeters
+	DefaultLegendColumnSpacing = 4.0
+
+	// DefaultTableFontSize is the default table font size in points
+	DefaultTableFontSize = 8.0
+
+	// DefaultTableCellPadding is the default space around the text of a table cell in millimeters
+	DefaultTableCellPadding = 1.0
+
+	// DefaultTableSwatchSize is the default size of the series color swatches in the table in millimeters
+	DefaultTableSwatchSize = 2.5
+
+	// DefaultTableLineThickness is the default thickness of the table rules in millimeters
+	DefaultTableLineThickness = 0.3
+
 	// DefaultPointSize is the default point size in millimeters
 	DefaultPointSize = 2.0
 
@@ -93,12 +188,18 @@ const (
 	// DefaultLegendFontSize is the default legend font size in points
 	DefaultLegendFontSize = 10.0
 
+	// DefaultLegendTitleFontSize is the default legend title font size in points
+	DefaultLegendTitleFontSize = 11.0
+
 	// DefaultAxisLabelFontSize is the default axis label font size in points
 	DefaultAxisLabelFontSize = 10.0
 
 	// DefaultTickLabelFontSize is the default tick label font size in points
 	DefaultTickLabelFontSize = 8.0
 
+	// DefaultInvertedMarker is the default text appended to the label of inverted axes
+	DefaultInvertedMarker = "(lower is better)"
+
 	smidge  = 1.000000001
 	mmPerPt = 0.3527777777777778
 )
@@ -0,0 +1,86 @@
+package spider
+
+import (
+	"math"
+
+	"github.com/tdewolff/canvas"
+)
+
+// curvePath returns the outline through the points with the given axis indices, in
+// order. Smooth series are drawn as a cardinal spline, other series with straight
+// lines. The outline is closed back to its first point if closed is set.
+func (c *Chart) curvePath(points []canvas.Point, run []int, closed bool, seriesOpts SeriesOptions) *canvas.Path {
+	path := &canvas.Path{}
+	if len(run) == 0 {
+		return path
+	}
+	path.MoveTo(points[run[0]].X, points[run[0]].Y)
+	smooth := seriesOpts.Curve == CurveSmooth && len(run) > 2
+	segments := len(run) - 1
+	if closed {
+		segments = len(run)
+	}
+	// neighbour returns the index into run k steps away from m, which stays at the
+	// ends of open outlines
+	neighbour := func(m int, k int) int {
+		if closed {
+			return run[((m+k)%len(run)+len(run))%len(run)]
+		}
+		return run[max(0, min(len(run)-1, m+k))]
+	}
+	for m := 0; m < segments; m++ {
+		a, b := neighbour(m, 0), neighbour(m, 1)
+		p := points[b]
+		if !smooth {
+			path.LineTo(p.X, p.Y)
+			continue
+		}
+		c1, c2 := c.splineControls(points[neighbour(m, -1)], points[a], p, points[neighbour(m, 2)], seriesOpts.Tension)
+		c1 = c.clampToSector(c1, a, b)
+		c2 = c.clampToSector(c2, a, b)
+		path.CubeTo(c1.X, c1.Y, c2.X, c2.Y, p.X, p.Y)
+	}
+	if closed {
+		path.Close()
+	}
+	return path
+}
+
+// splineControls returns the Bézier control points of the cardinal spline segment
+// from p1 to p2, with p0 and p3 the points before and after them. A tension of 0 gives
+// a Catmull-Rom spline and a tension of 1 gives straight lines.
+func (c *Chart) splineControls(p0, p1, p2, p3 canvas.Point, tension float64) (canvas.Point, canvas.Point) {
+	k := (1 - tension) / 6
+	c1 := p1.Add(p2.Sub(p0).Mul(k))
+	c2 := p2.Sub(p3.Sub(p1).Mul(k))
+	return c1, c2
+}
+
+// clampToSector moves a control point of the segment between the axes with index a and
+// b into the sector between those axes and inside the outer ring. The sector and the
+// disk are convex, so a Bézier segment with its control points inside them does not
+// overshoot past the center or the outer ring.
+func (c *Chart) clampToSector(p canvas.Point, a int, b int) canvas.Point {
+	center := c.plotRect.Center()
+	v := p.Sub(center)
+	n := len(c.Data.Axes)
+	span := float64((b-a+n)%n) * Tau / float64(n)
+	if span > 0 && span <= math.Pi {
+		theta0 := c.axisAngle(a)
+		// Angle of v measured counter-clockwise from the first axis of the sector
+		phi := math.Mod(math.Atan2(v.Y, v.X)-theta0+2*Tau, Tau)
+		if phi > span {
+			// Project onto the nearest edge of the sector
+			edge := theta0 + span
+			if Tau-phi < phi-span {
+				edge = theta0
+			}
+			dir := canvas.Point{X: math.Cos(edge), Y: math.Sin(edge)}
+			v = dir.Mul(math.Max(0, v.Dot(dir)))
+		}
+	}
+	if r := v.Length(); r > c.Radius() {
+		v = v.Mul(c.Radius() / r)
+	}
+	return center.Add(v)
+}
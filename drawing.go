@@ -1,8 +1,9 @@
 package spider
 
 import (
-	"fmt"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/tdewolff/canvas"
 )
@@ -59,27 +60,89 @@ func (c *Chart) drawPlotBackground(ctx *canvas.Context) {
 
 	centerX := c.plotRect.X0 + c.plotRect.W()/2
 	centerY := c.plotRect.Y0 + c.plotRect.H()/2
+	if shape := c.plotShape(c.Radius()); shape != nil {
+		ctx.DrawPath(centerX, centerY, shape)
+		ctx.Stroke()
+	}
+}
+
+// plotShape returns the outline of the plot at radius r, centered on the origin, or
+// nil if the plot has no outline
+func (c *Chart) plotShape(r float64) *canvas.Path {
 	switch c.Options.PlotOptions.ConnectType {
 	case ConnectTypeCircle:
-		circle := canvas.Circle(c.Radius())
-		ctx.DrawPath(centerX, centerY, circle)
-		ctx.Stroke()
+		return canvas.Circle(r)
 	case ConnectTypePolygon:
 		nAxes := len(c.Data.Axes)
 		if nAxes > 0 {
-			polygon := canvas.RegularPolygon(nAxes, c.Radius(), true)
 			// For odd number of sides, RegularPolygon has a vertex at the top by default
-			ctx.DrawPath(centerX, centerY, polygon)
-			ctx.Stroke()
+			return canvas.RegularPolygon(nAxes, r, true)
+		}
+	}
+	return nil
+}
+
+// drawGrid draws the concentric grid rings and alternating bands inside the plot
+func (c *Chart) drawGrid(ctx *canvas.Context) {
+	grid := c.Options.PlotOptions.Grid
+	if !grid.Show && !grid.ShowMinor {
+		return
+	}
+	centerX := c.plotRect.X0 + c.plotRect.W()/2
+	centerY := c.plotRect.Y0 + c.plotRect.H()/2
+	radius := c.Radius()
+	major, minor := c.gridFractions()
+
+	// Fill every other band between the major rings, starting with the second band
+	if grid.Show && grid.BandColor != "" {
+		bounds := append(append([]float64{0}, major...), 1)
+		ctx.SetStrokeColor(canvas.Transparent)
+		ctx.SetFillColor(grid.BandColor.ToCanvasColorWithOpacity(grid.BandOpacity))
+		for i := 1; i < len(bounds)-1; i += 2 {
+			outer := c.plotShape(bounds[i+1] * radius)
+			inner := c.plotShape(bounds[i] * radius)
+			if outer == nil || inner == nil {
+				break
+			}
+			ctx.DrawPath(centerX, centerY, outer.Append(inner.Reverse()))
+			ctx.Fill()
+		}
+	}
+
+	ctx.SetFillColor(canvas.Transparent)
+	if grid.ShowMinor {
+		ctx.SetStrokeColor(grid.MinorColor.ToCanvasColor())
+		ctx.SetStrokeWidth(grid.MinorThickness)
+		for _, f := range minor {
+			if ring := c.plotShape(f * radius); ring != nil {
+				ctx.DrawPath(centerX, centerY, dashPath(ring, grid.Dashes))
+				ctx.Stroke()
+			}
+		}
+	}
+	if grid.Show {
+		ctx.SetStrokeColor(grid.Color.ToCanvasColor())
+		ctx.SetStrokeWidth(grid.Thickness)
+		for _, f := range major {
+			if ring := c.plotShape(f * radius); ring != nil {
+				ctx.DrawPath(centerX, centerY, dashPath(ring, grid.Dashes))
+				ctx.Stroke()
+			}
 		}
 	}
 }
 
+// dashPath applies a dash pattern in millimeters to p. Dashing the path itself rather
+// than setting the context dashes keeps the pattern identical in all renderers.
+func dashPath(p *canvas.Path, dashes []float64) *canvas.Path {
+	if len(dashes) == 0 {
+		return p
+	}
+	return p.Dash(0, dashes...)
+}
+
 // drawAxes draws all axes and their labels
 func (c *Chart) drawAxes(ctx *canvas.Context) {
-	ctx.SetStrokeColor(canvas.Black)
-	ctx.SetStrokeWidth(DefaultAxisLineThickness)
-
 	nAxes := len(c.Data.Axes)
 
 	centerX := c.plotRect.X0 + c.plotRect.W()/2
@@ -92,153 +155,333 @@ func (c *Chart) drawAxes(ctx *canvas.Context) {
 	theta := 90.0
 
 	// Get all series data for max calculation
-	seriesData := getAllSeriesData(c.Data.Series)
-	ctx.SetStrokeColor(canvas.Black)
-	ctx.SetStrokeWidth(c.Options.AxisOptions.LineThickness)
-	labelOffset := c.Options.AxisOptions.LabelOffset
-	for _, axis := range c.Data.Axes {
+	seriesData := c.scaleData()
+	for i := range c.Data.Axes {
+		axis := &c.Data.Axes[i]
+		opts := c.axisOptions(axis)
+		lineColor := opts.LineColor.ToCanvasColor()
 		// Draw axis line using Push/Pop with transformations
 		ctx.Push()
 		ctx.Translate(centerX, centerY) // Move origin to center
 		ctx.Rotate(theta)               // Rotate around the new origin
-		ctx.MoveTo(0, 0)                // Start at origin (center)
-		ctx.LineTo(radius, 0)           // Draw line along rotated x-axis
-		ctx.Stroke()
-		// Draw axis name
-		if c.Options.ShowAxisNames {
-			ctx.Push()
-			ctx.Translate(radius+labelOffset, 0)
-			if theta > 180 && theta < 360 {
-				ctx.Rotate(90)
-			} else {
-				ctx.Rotate(-90)
-			}
-			ctx.DrawText(0, 0, canvas.NewTextLine(c.fonts["axis_label"], axis.Name, canvas.Center))
-			ctx.Pop()
+		ctx.SetStrokeColor(lineColor)
+		if opts.ShowAxis {
+			ctx.SetStrokeWidth(opts.LineThickness)
+			ctx.MoveTo(0, 0)      // Start at origin (center)
+			ctx.LineTo(radius, 0) // Draw line along rotated x-axis
+			ctx.Stroke()
 		}
 		// Draw ticks
-		max := axis.GetMax(seriesData)
+		showTicks := c.Options.ShowTicks && opts.ShowTicks
+		showTickLabels := c.Options.ShowTickLabels && opts.ShowTickLabels
+		scale := axis.scale(seriesData, opts)
 		// major ticks
-		majorTicks := linspace(0, radius, c.Options.AxisOptions.MajorTicks+2)
-		ctx.SetStrokeWidth(c.Options.AxisOptions.MajorTickLineThickness)
-		for i := 1; i < len(majorTicks)-1; i++ {
-			l := c.Options.AxisOptions.MajorTickLength / 2
-			o := c.Options.AxisOptions.LabelOffset
-			val := linmap(0, radius, 0, max, majorTicks[i])
-			label := val2String(val)
-			ctx.SetStrokeWidth(c.Options.AxisOptions.MajorTickLineThickness)
-			ctx.MoveTo(majorTicks[i], -l)
-			ctx.LineTo(majorTicks[i], l)
-			ctx.Stroke()
+		majorTicks := scale.majorTicks(opts.MajorTicks)
+		for _, val := range majorTicks {
+			f := scale.fraction(val)
+			if !isInterior(f) {
+				continue
+			}
+			r := f * radius
+			l := opts.MajorTickLength / 2
+			o := opts.LabelOffset
+			label := opts.TickLabelFormat.FormatValue(val)
+			if showTicks {
+				ctx.SetStrokeWidth(opts.MajorTickLineThickness)
+				ctx.MoveTo(r, -l)
+				ctx.LineTo(r, l)
+				ctx.Stroke()
+			}
 			// tick labels
-			if c.Options.ShowTickLabels {
+			if showTickLabels {
 				ctx.Push()
-				ctx.Translate(majorTicks[i], -l-o)
+				ctx.Translate(r, -l-o)
 				ctx.Rotate(-theta)
-				ctx.DrawText(0, 0, canvas.NewTextLine(c.fonts["tick_label"], label, canvas.Center))
+				ctx.DrawText(0, 0, canvas.NewTextLine(c.axisFont(axis, "tick_label"), label, canvas.Center))
 				ctx.Pop()
 			}
 		}
 		// minor ticks
-		ctx.SetStrokeWidth(c.Options.AxisOptions.MinorTickLineThickness)
-		for i := 0; i < len(majorTicks)-1; i++ {
-			minorTicks := linspace(majorTicks[i], majorTicks[i+1], c.Options.AxisOptions.MinorTicks+2)
-			for j := 1; j < len(minorTicks)-1; j++ {
-				ctx.MoveTo(minorTicks[j], -c.Options.AxisOptions.MinorTickLength/2)
-				ctx.LineTo(minorTicks[j], c.Options.AxisOptions.MinorTickLength/2)
+		if showTicks {
+			ctx.SetStrokeWidth(opts.MinorTickLineThickness)
+			for _, val := range scale.minorTicks(majorTicks, opts.MinorTicks) {
+				f := scale.fraction(val)
+				if !isInterior(f) {
+					continue
+				}
+				r := f * radius
+				ctx.MoveTo(r, -opts.MinorTickLength/2)
+				ctx.LineTo(r, opts.MinorTickLength/2)
 				ctx.Stroke()
 			}
 		}
 		ctx.Pop() // Restore previous transformation state
 		theta += dt
 	}
+	c.drawAxisNames(ctx)
 }
 
-// drawSeries draws all series on the chart
-func (c *Chart) drawSeries(ctx *canvas.Context) {
-	nAxes := len(c.Data.Axes)
+// isHighlighted returns true if the series with the given index is highlighted
+func (c *Chart) isHighlighted(i int) bool {
+	return slices.Contains(c.Options.Highlight, c.Data.Series[i].Name)
+}
 
-	seriesData := getAllSeriesData(c.Data.Series)
+// isDimmed returns true if the series with the given index is drawn dimmed behind the
+// highlighted series. The reference series is never dimmed.
+func (c *Chart) isDimmed(i int) bool {
+	return len(c.Options.Highlight) > 0 && !c.isHighlighted(i) && !c.isReference(i)
+}
 
-	centerX := c.plotRect.X0 + c.plotRect.W()/2
-	centerY := c.plotRect.Y0 + c.plotRect.H()/2
-	radius := c.Radius()
+// seriesOrder returns the indices of the visible series in drawing order: dimmed
+// series first, then by z_order and finally by their position in the data. The
+// reference series is always drawn last so that it is on top.
+func (c *Chart) seriesOrder() []int {
+	order := make([]int, 0, len(c.Data.Series))
+	for i := range c.Data.Series {
+		if c.Data.Series[i].IsVisible() {
+			order = append(order, i)
+		}
+	}
+	rank := func(i int) []int {
+		return []int{boolToInt(c.isReference(i)), boolToInt(!c.isDimmed(i)), c.Data.Series[i].Options.ZOrder}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return slices.Compare(rank(a), rank(b))
+	})
+	return order
+}
 
-	// Calculate starting angle (same as in drawAxes) - start at top (π/2)
-	startTheta := math.Pi / 2
-	dt := Tau / float64(nAxes)
+// boolToInt returns 1 for true and 0 for false
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
 
-	nColors := len(c.Options.Colors)
-	nPointMarkers := len(c.Options.PointMarkers)
-	for i := range c.Data.Series {
+// drawSeries draws all series on the chart
+func (c *Chart) drawSeries(ctx *canvas.Context) {
+	scales := c.axisScales()
+	mode := c.Options.MissingValues
+
+	// Shade the differences to the reference series below all series
+	c.drawDeltas(ctx, scales)
+	for _, i := range c.seriesOrder() {
 		series := &c.Data.Series[i]
-		// Apply default colors and point markers if not set
-		seriesOpts := SeriesOptions{
-			LineColor:          series.Options.LineColor,
-			FillColor:          series.Options.FillColor,
-			PointStrokeColor:   series.Options.PointStrokeColor,
-			PointFillColor:     series.Options.PointFillColor,
-			PointFillOpacity:   series.Options.PointFillOpacity,
-			PointShape:         series.Options.PointShape,
-			PointSize:          series.Options.PointSize,
-			PointLineThickness: series.Options.PointLineThickness,
-			LineThickness:      series.Options.LineThickness,
-			FillOpacity:        series.Options.FillOpacity,
+		seriesOpts := c.seriesOptions(i)
+		points, present := c.seriesPoints(i, scales)
+		// draw uncertainty band below the series line
+		if len(series.ranges) > 0 && seriesOpts.RangeStyle == RangeStyleBand {
+			c.drawSeriesBand(ctx, series, seriesOpts, scales)
 		}
-		if seriesOpts.LineColor == "" {
-			seriesOpts.LineColor = c.Options.Colors[i%nColors]
+		// draw older snapshots below the latest one
+		c.drawHistory(ctx, i, seriesOpts, scales)
+		// draw series fill, across gaps when missing values break the outline
+		if mode == MissingValueCenter {
+			for j := range present {
+				present[j] = true
+			}
 		}
-		if seriesOpts.PointStrokeColor == "" {
-			seriesOpts.PointStrokeColor = c.Options.Colors[i%nColors]
+		fill := c.seriesPath(points, present, seriesOpts)
+		outline := fill
+		if mode == MissingValueGap {
+			outline = c.seriesGapPath(points, present, seriesOpts)
 		}
-		if seriesOpts.PointFillColor == "" {
-			seriesOpts.PointFillColor = c.Options.Colors[i%nColors]
+		c.fillSeriesPath(ctx, fill, seriesOpts)
+		// draw series line
+		c.strokeSeriesPath(ctx, outline, seriesOpts, seriesOpts.LineThickness)
+		// draw error bars above the series line
+		if len(series.ranges) > 0 && seriesOpts.RangeStyle == RangeStyleErrorBars {
+			c.drawSeriesErrorBars(ctx, series, seriesOpts, scales)
 		}
-		if series.Options.LineColor == "" {
-			seriesOpts.LineColor = c.Options.Colors[i%nColors]
+		c.drawHistoryArrows(ctx, i, seriesOpts, scales)
+		// draw series points, with hollow markers for missing values at the center
+		if c.Options.ShowPointMarkers {
+			hollowOpts := seriesOpts
+			hollowOpts.PointFillOpacity = 0
+			for j, point := range points {
+				if _, ok := series.value(c.Data.Axes[j].Name); ok {
+					c.drawSeriesPoint(ctx, point, seriesOpts)
+				} else if mode == MissingValueCenter {
+					c.drawSeriesPoint(ctx, point, hollowOpts)
+				}
+			}
 		}
-		if series.Options.PointStrokeColor == "" {
-			seriesOpts.PointStrokeColor = c.Options.Colors[i%nColors]
+	}
+}
+
+// strokeSeriesPath strokes a path with the line style of a series
+func (c *Chart) strokeSeriesPath(ctx *canvas.Context, p *canvas.Path, seriesOpts SeriesOptions, width float64) {
+	ctx.Push()
+	ctx.SetFillColor(canvas.Transparent)
+	ctx.SetStrokeColor(seriesOpts.LineColor.ToCanvasColor())
+	ctx.SetStrokeWidth(width)
+	ctx.SetStrokeCapper(seriesOpts.LineCap.capper())
+	ctx.SetStrokeJoiner(seriesOpts.LineJoin.joiner())
+	ctx.DrawPath(0, 0, dashPath(p, seriesOpts.dashPattern(width)))
+	ctx.Pop()
+}
+
+// seriesPoints returns the point of a series on every axis and whether the series has
+// a value for it. Missing values are placed at the center.
+func (c *Chart) seriesPoints(i int, scales []axisScale) ([]canvas.Point, []bool) {
+	return c.dataPoints(c.Data.Series[i].values, scales)
+}
+
+// dataPoints returns the point of the data values on every axis and whether there is
+// a value for it. Missing values are placed at the center.
+func (c *Chart) dataPoints(data map[string]float64, scales []axisScale) ([]canvas.Point, []bool) {
+	radius := c.Radius()
+	points := make([]canvas.Point, len(c.Data.Axes))
+	present := make([]bool, len(c.Data.Axes))
+	for j, axis := range c.Data.Axes {
+		value, ok := data[axis.Name]
+		present[j] = ok
+		r := 0.0
+		if ok {
+			r = scales[j].radius(value, radius)
 		}
-		if series.Options.PointFillColor == "" {
-			seriesOpts.PointFillColor = c.Options.Colors[i%nColors]
+		points[j] = c.axisPoint(j, r)
+	}
+	return points, present
+}
+
+// seriesPath returns the closed outline through the points that are present
+func (c *Chart) seriesPath(points []canvas.Point, present []bool, seriesOpts SeriesOptions) *canvas.Path {
+	var run []int
+	for j := range points {
+		if present[j] {
+			run = append(run, j)
 		}
-		if series.Options.PointShape == "" {
-			seriesOpts.PointShape = c.Options.PointMarkers[i%nPointMarkers]
+	}
+	return c.curvePath(points, run, true, seriesOpts)
+}
+
+// seriesGapPath returns the outline of a series as open runs of neighbouring points
+// that are present, broken at every missing point. When no point is missing the
+// outline is closed.
+func (c *Chart) seriesGapPath(points []canvas.Point, present []bool, seriesOpts SeriesOptions) *canvas.Path {
+	n := len(points)
+	start := -1
+	for j := range points {
+		if !present[j] {
+			start = j
+			break
 		}
-		if series.Options.PointSize == 0 {
-			seriesOpts.PointSize = DefaultPointSize
+	}
+	if start < 0 {
+		return c.seriesPath(points, present, seriesOpts)
+	}
+	// Walk around the polygon starting after a missing point so that runs which wrap
+	// around the first axis stay in one piece
+	path := &canvas.Path{}
+	var run []int
+	for k := 1; k <= n; k++ {
+		j := (start + k) % n
+		if present[j] {
+			run = append(run, j)
+			continue
 		}
-		if series.Options.PointLineThickness == 0 {
-			seriesOpts.PointLineThickness = DefaultSeriesLineThickness
+		if len(run) > 0 {
+			path = path.Append(c.curvePath(points, run, false, seriesOpts))
+			run = nil
 		}
-		// Calculate points for this series
-		points := make([]canvas.Point, nAxes)
-		theta := startTheta
-		for j, axis := range c.Data.Axes {
-			max := axis.GetMax(seriesData)
-			value := series.GetDataValue(axis.Name)
-			scaledRadius := linmap(0, max, 0, radius, value)
-			points[j].X = centerX + scaledRadius*math.Cos(theta)
-			points[j].Y = centerY + scaledRadius*math.Sin(theta)
-			theta += dt
+	}
+	return path
+}
+
+// seriesRangeRadii returns the inner and outer radius of the uncertainty range of a
+// series on each axis. Axes without a range collapse to the value itself, and axes
+// without a value are NaN unless missing values are drawn at the center.
+func (c *Chart) seriesRangeRadii(series *Series, scales []axisScale) ([]float64, []float64) {
+	radius := c.Radius()
+	inner := make([]float64, len(c.Data.Axes))
+	outer := make([]float64, len(c.Data.Axes))
+	for j, axis := range c.Data.Axes {
+		value, ok := series.value(axis.Name)
+		if !ok {
+			if c.Options.MissingValues == MissingValueCenter {
+				inner[j], outer[j] = 0, 0
+			} else {
+				inner[j], outer[j] = math.NaN(), math.NaN()
+			}
+			continue
 		}
-		// draw series line
-		ctx.SetFillColor(seriesOpts.FillColor.ToCanvasColorWithOpacity(seriesOpts.FillOpacity))
-		ctx.SetStrokeColor(seriesOpts.LineColor.ToCanvasColor())
-		ctx.SetStrokeWidth(seriesOpts.LineThickness)
-		ctx.MoveTo(points[0].X, points[0].Y)
-		for j := 1; j < len(points); j++ {
-			ctx.LineTo(points[j].X, points[j].Y)
+		low, high := value, value
+		if r, ok := series.ranges[axis.Name]; ok {
+			low, high = r.Bounds(value)
 		}
-		ctx.Close()
-		ctx.FillStroke()
-		// draw series points
-		if c.Options.ShowPointMarkers {
-			for _, point := range points {
-				c.drawSeriesPoint(ctx, point, seriesOpts)
-			}
+		// Inverted axes put the high end closer to the center
+		rLow := scales[j].radius(low, radius)
+		rHigh := scales[j].radius(high, radius)
+		inner[j] = math.Min(rLow, rHigh)
+		outer[j] = math.Max(rLow, rHigh)
+	}
+	return inner, outer
+}
+
+// drawSeriesBand fills the area between the inner and outer polygons of the
+// uncertainty ranges of a series
+func (c *Chart) drawSeriesBand(ctx *canvas.Context, series *Series, seriesOpts SeriesOptions, scales []axisScale) {
+	inner, outer := c.seriesRangeRadii(series, scales)
+	n := len(c.Data.Axes)
+	outerPoints := make([]canvas.Point, n)
+	innerPoints := make([]canvas.Point, n)
+	present := make([]bool, n)
+	for j := range outer {
+		outerPoints[j] = c.axisPoint(j, outer[j])
+		innerPoints[j] = c.axisPoint(j, inner[j])
+		present[j] = !math.IsNaN(outer[j])
+	}
+	// The inner outline runs in the opposite direction to cut a hole into the band
+	band := c.seriesPath(outerPoints, present, seriesOpts)
+	band = band.Append(c.seriesPath(innerPoints, present, seriesOpts).Reverse())
+	ctx.SetStrokeColor(canvas.Transparent)
+	ctx.SetFillColor(seriesOpts.RangeColor.ToCanvasColorWithOpacity(seriesOpts.RangeOpacity))
+	ctx.DrawPath(0, 0, band)
+}
+
+// drawSeriesErrorBars draws radial error bars with caps along each axis of a series
+func (c *Chart) drawSeriesErrorBars(ctx *canvas.Context, series *Series, seriesOpts SeriesOptions, scales []axisScale) {
+	inner, outer := c.seriesRangeRadii(series, scales)
+	ctx.SetFillColor(canvas.Transparent)
+	ctx.SetStrokeColor(seriesOpts.RangeColor.ToCanvasColor())
+	ctx.SetStrokeWidth(seriesOpts.LineThickness)
+	w := seriesOpts.ErrorBarCapWidth / 2
+	for j, axis := range c.Data.Axes {
+		if _, ok := series.ranges[axis.Name]; !ok || math.IsNaN(outer[j]) {
+			continue
 		}
+		theta := c.axisAngle(j)
+		// Unit vector perpendicular to the axis for the caps
+		dx, dy := -math.Sin(theta)*w, math.Cos(theta)*w
+		p0 := c.axisPoint(j, inner[j])
+		p1 := c.axisPoint(j, outer[j])
+		ctx.MoveTo(p0.X, p0.Y)
+		ctx.LineTo(p1.X, p1.Y)
+		for _, p := range []canvas.Point{p0, p1} {
+			ctx.MoveTo(p.X-dx, p.Y-dy)
+			ctx.LineTo(p.X+dx, p.Y+dy)
+		}
+		ctx.Stroke()
+	}
+}
+
+// axisAngle returns the angle in radians of the axis with the given index, starting
+// at the top (π/2) and going counter-clockwise
+func (c *Chart) axisAngle(j int) float64 {
+	return math.Pi/2 + float64(j)*Tau/float64(len(c.Data.Axes))
+}
+
+// axisPoint returns the point at distance r from the plot center along the axis with
+// the given index
+func (c *Chart) axisPoint(j int, r float64) canvas.Point {
+	centerX := c.plotRect.X0 + c.plotRect.W()/2
+	centerY := c.plotRect.Y0 + c.plotRect.H()/2
+	theta := c.axisAngle(j)
+	return canvas.Point{
+		X: centerX + r*math.Cos(theta),
+		Y: centerY + r*math.Sin(theta),
 	}
 }
 
@@ -248,142 +491,197 @@ func (c *Chart) drawSeriesPoint(ctx *canvas.Context, point canvas.Point, seriesO
 	ctx.SetStrokeColor(seriesOpts.PointStrokeColor.ToCanvasColor())
 	ctx.SetStrokeWidth(seriesOpts.PointLineThickness)
 
-	switch seriesOpts.PointShape {
-	case PointShapeCircle:
-		circle := canvas.Circle(seriesOpts.PointSize / 2)
-		ctx.DrawPath(point.X, point.Y, circle)
-		ctx.FillStroke()
-	case PointShapeSquare:
-		rect := canvas.Rectangle(seriesOpts.PointSize, seriesOpts.PointSize)
-		ctx.DrawPath(point.X-seriesOpts.PointSize/2, point.Y-seriesOpts.PointSize/2, rect)
-		ctx.FillStroke()
-	case PointShapeTriangle:
-		triangle := canvas.RegularPolygon(3, seriesOpts.PointSize/2, true)
-		ctx.DrawPath(point.X, point.Y, triangle)
-		ctx.FillStroke()
-	case PointShapeDiamond:
-		diamond := canvas.RegularPolygon(4, seriesOpts.PointSize/2, true)
-		ctx.DrawPath(point.X, point.Y, diamond)
-		ctx.FillStroke()
-	}
-}
-
-// drawLegend draws the legend on the canvas
+	if marker := markerPath(seriesOpts.PointShape, seriesOpts.PointSize, seriesOpts.PointPath); marker != nil {
+		ctx.DrawPath(point.X, point.Y, marker)
+	}
+}
+
+// drawLegend draws the legend on the canvas, on its background and in its outline if
+// they are set
 func (c *Chart) drawLegend(ctx *canvas.Context) {
-	legend := c.Options.LegendOptions
-	if !legend.Show || len(c.Data.Series) == 0 {
+	if !c.hasLegend() {
 		return
 	}
+	legend := c.Options.LegendOptions
+	if legend.Background != "" || legend.ShowOutline {
+		ctx.Push()
+		ctx.SetFillColor(canvas.Transparent)
+		if legend.Background != "" {
+			ctx.SetFillColor(legend.Background.ToCanvasColor())
+		}
+		ctx.SetStrokeColor(canvas.Transparent)
+		if legend.ShowOutline {
+			ctx.SetStrokeColor(legend.OutlineColor.ToCanvasColor())
+			ctx.SetStrokeWidth(legend.OutlineThickness)
+		}
+		ctx.DrawPath(c.legendRect.X0, c.legendRect.Y0, canvas.Rectangle(c.legendRect.W(), c.legendRect.H()))
+		ctx.Pop()
+	}
+	rect := c.legendRect.Expand(-legend.Padding)
+	ctx.DrawText(rect.X0, rect.Y1, c.legendText(c.legendBreakWidth(), rect.W()*smidge, rect.H()))
+}
 
-	var legendHorizontalTextAlignment canvas.TextAlign
-	var legendVerticalTextAlignment canvas.TextAlign
-	switch legend.Placement {
-	case LegendPlacementTop:
-		legendHorizontalTextAlignment = canvas.Center
-		legendVerticalTextAlignment = canvas.Bottom
-	case LegendPlacementBottom:
-		legendHorizontalTextAlignment = canvas.Center
-		legendVerticalTextAlignment = canvas.Top
-	case LegendPlacementLeft:
-		legendHorizontalTextAlignment = canvas.Right
-		legendVerticalTextAlignment = canvas.Center
-	case LegendPlacementRight:
-		legendHorizontalTextAlignment = canvas.Left
-		legendVerticalTextAlignment = canvas.Center
-	default:
-		legendHorizontalTextAlignment = canvas.Center
-		legendVerticalTextAlignment = canvas.Center
-	}
-
-	// Draw legend items
+// hasLegend returns true if the legend is shown and lists at least one series. The
+// legend is hidden by show_legend, legend_options.show or the none placement alike.
+func (c *Chart) hasLegend() bool {
+	legend := c.Options.LegendOptions
+	return c.Options.ShowLegend && legend.Show && legend.Placement != LegendPlacementNone && len(c.legendItems()) > 0
+}
+
+// legendText returns the legend title and entries as a text box of the given size. A
+// width of 0 does not limit the width of the lines.
+func (c *Chart) legendText(breakWidth, width, height float64) *canvas.Text {
+	legend := c.Options.LegendOptions
 	rt := canvas.NewRichText(c.fonts["legend_label"])
 	textOptions := &canvas.TextOptions{
 		Linebreaker: canvas.KnuthLinebreaker{},
 	}
-	w := c.legendRect.W()
+	if legend.Title != "" {
+		rt.WriteFace(c.fonts["legend_title"], legend.Title+"\n")
+	}
+	items := c.legendItems()
+	if rows, cols := c.legendGrid(len(items)); cols > 0 {
+		c.writeLegendGrid(rt, items, rows, cols)
+	} else {
+		c.writeLegendFlow(rt, items, breakWidth)
+	}
+	halign, valign := legend.Placement.alignment()
+	return rt.ToText(width, height, halign, valign, textOptions)
+}
+
+// writeLegendFlow writes the legend entries one after the other. A line is broken
+// after the entry that makes it wider than most of the break width, and legends
+// beside the plot or in its corners list one entry per line.
+func (c *Chart) writeLegendFlow(rt *canvas.RichText, items []int, breakWidth float64) {
+	stacked := c.Options.LegendOptions.Placement.stacked()
 	dw := 0.0
-	for i, series := range c.Data.Series {
-		cnvs, width := c.drawLegendSeriesPath(i)
+	for k, i := range items {
+		swatch, label, width := c.legendEntry(i)
 		dw += width
-		rt.WriteCanvas(cnvs, canvas.FontMiddle)
-		cnvs, width = c.canvasString(series.Name)
-		dw += width
-		rt.WriteCanvas(cnvs, canvas.FontMiddle)
-		if (legend.Placement == LegendPlacementRight || legend.Placement == LegendPlacementLeft) && i < len(c.Data.Series)-1 {
+		rt.WriteCanvas(swatch, canvas.FontMiddle)
+		rt.WriteCanvas(label, canvas.FontMiddle)
+		if stacked && k < len(items)-1 {
 			rt.WriteString("\n")
 			dw = 0.0
 		} else {
-			cnvs, width := c.canvasString(" ")
+			cnvs, width := c.canvasString(c.legendFace(i), " ")
 			dw += width
 			rt.WriteCanvas(cnvs, canvas.FontMiddle)
 		}
-		if dw > 0.85*w {
+		if dw > 0.85*breakWidth && k < len(items)-1 {
 			rt.WriteString("\n")
 			dw = 0.0
 		}
 	}
-	text := rt.ToText(c.legendRect.W(), c.legendRect.H(), legendHorizontalTextAlignment, legendVerticalTextAlignment, textOptions)
-	ctx.DrawText(c.legendRect.X0, c.legendRect.Y1, text)
 }
 
-func (c *Chart) canvasString(s string) (*canvas.Canvas, float64) {
+// writeLegendGrid writes the legend entries in rows and columns, in reading order.
+// Every entry is padded to the width of its column so that the columns line up.
+func (c *Chart) writeLegendGrid(rt *canvas.RichText, items []int, rows, cols int) {
+	type entry struct {
+		swatch, label *canvas.Canvas
+		width         float64
+	}
+	entries := make([]entry, len(items))
+	colWidths := make([]float64, cols)
+	for k, i := range items {
+		swatch, label, width := c.legendEntry(i)
+		entries[k] = entry{swatch, label, width}
+		colWidths[k%cols] = math.Max(colWidths[k%cols], width)
+	}
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			rt.WriteString("\n")
+		}
+		for col := 0; col < cols; col++ {
+			pad := colWidths[col]
+			if k := row*cols + col; k < len(entries) {
+				rt.WriteCanvas(entries[k].swatch, canvas.FontMiddle)
+				rt.WriteCanvas(entries[k].label, canvas.FontMiddle)
+				pad -= entries[k].width
+			}
+			if col < cols-1 {
+				pad += DefaultLegendColumnSpacing
+			}
+			if pad > 0 {
+				rt.WriteCanvas(canvas.New(pad, 0), canvas.FontMiddle)
+			}
+		}
+	}
+}
+
+// legendEntry returns the swatch and the label of the legend entry of the series with
+// index i, and their total width
+func (c *Chart) legendEntry(i int) (*canvas.Canvas, *canvas.Canvas, float64) {
+	swatch, swatchWidth := c.drawLegendSeriesPath(i)
+	label, labelWidth := c.canvasString(c.legendFace(i), c.legendLabel(i))
+	return swatch, label, swatchWidth + labelWidth
+}
+
+// legendItems returns the indices of the series listed in the legend, in legend
+// order: the visible series, and the hidden series as well if they are shown grayed
+// out
+func (c *Chart) legendItems() []int {
+	var items []int
+	for i := range c.Data.Series {
+		if c.Data.Series[i].IsVisible() || c.Options.LegendOptions.ShowHidden {
+			items = append(items, i)
+		}
+	}
+	switch c.Options.LegendOptions.Order {
+	case LegendOrderReverse:
+		slices.Reverse(items)
+	case LegendOrderName:
+		slices.SortStableFunc(items, func(a, b int) int {
+			return strings.Compare(c.Data.Series[a].Name, c.Data.Series[b].Name)
+		})
+	}
+	return items
+}
+
+// legendFace returns the font face of the legend label of the series with index i
+func (c *Chart) legendFace(i int) *canvas.FontFace {
+	if !c.Data.Series[i].IsVisible() {
+		return c.hiddenLegendFace()
+	}
+	return c.fonts["legend_label"]
+}
+
+// hiddenLegendFace returns the font face of the legend labels of hidden series
+func (c *Chart) hiddenLegendFace() *canvas.FontFace {
+	face := *c.fonts["legend_label"]
+	col := c.Options.LegendOptions.LegendStyle.Color.dimmed(1, DefaultDimOpacity)
+	face.Fill = canvas.Paint{Color: toRGBA(col.ToCanvasColor())}
+	return &face
+}
+
+func (c *Chart) canvasString(face *canvas.FontFace, s string) (*canvas.Canvas, float64) {
 	cnvs := canvas.New(10, 10)
 	ctx := canvas.NewContext(cnvs)
-	ctx.DrawText(0, 0, canvas.NewTextLine(c.fonts["legend_label"], s, canvas.Center))
+	ctx.DrawText(0, 0, canvas.NewTextLine(face, s, canvas.Center))
 	cnvs.Fit(0)
 	width, _ := cnvs.Size()
 	return cnvs, width
 }
 
 func (c *Chart) drawLegendSeriesPath(seriesIndex int) (*canvas.Canvas, float64) {
-	seriesOpts := SeriesOptions{
-		LineColor:          c.Data.Series[seriesIndex].Options.LineColor,
-		FillColor:          c.Data.Series[seriesIndex].Options.FillColor,
-		PointStrokeColor:   c.Data.Series[seriesIndex].Options.PointStrokeColor,
-		PointFillColor:     c.Data.Series[seriesIndex].Options.PointFillColor,
-		PointFillOpacity:   c.Data.Series[seriesIndex].Options.PointFillOpacity,
-		PointShape:         c.Data.Series[seriesIndex].Options.PointShape,
-		PointSize:          c.Data.Series[seriesIndex].Options.PointSize,
-		PointLineThickness: c.Data.Series[seriesIndex].Options.PointLineThickness,
-		LineThickness:      c.Data.Series[seriesIndex].Options.LineThickness,
-		FillOpacity:        c.Data.Series[seriesIndex].Options.FillOpacity,
-	}
-	nColors := len(c.Options.Colors)
-	nPointMarkers := len(c.Options.PointMarkers)
-	if seriesOpts.LineColor == "" {
-		seriesOpts.LineColor = c.Options.Colors[seriesIndex%nColors]
-	}
-	if seriesOpts.PointStrokeColor == "" {
-		seriesOpts.PointStrokeColor = c.Options.Colors[seriesIndex%nColors]
-	}
-	if seriesOpts.PointFillColor == "" {
-		seriesOpts.PointFillColor = c.Options.Colors[seriesIndex%nColors]
-	}
-	if seriesOpts.LineColor == "" {
-		seriesOpts.LineColor = c.Options.Colors[seriesIndex%nColors]
-	}
-	if seriesOpts.PointStrokeColor == "" {
-		seriesOpts.PointStrokeColor = c.Options.Colors[seriesIndex%nColors]
-	}
-	if seriesOpts.PointFillColor == "" {
-		seriesOpts.PointFillColor = c.Options.Colors[seriesIndex%nColors]
-	}
-	if seriesOpts.PointShape == "" {
-		seriesOpts.PointShape = c.Options.PointMarkers[seriesIndex%nPointMarkers]
-	}
-	if seriesOpts.PointSize == 0 {
-		seriesOpts.PointSize = DefaultPointSize
-	}
-	if seriesOpts.PointLineThickness == 0 {
-		seriesOpts.PointLineThickness = DefaultSeriesLineThickness
+	seriesOpts := c.seriesOptions(seriesIndex)
+	if !c.Data.Series[seriesIndex].IsVisible() {
+		seriesOpts = seriesOpts.dimmed(1, DefaultDimOpacity)
 	}
 	cnvs := canvas.New(10, 10)
 	ctx := canvas.NewContext(cnvs)
-	ctx.SetStrokeColor(seriesOpts.LineColor.ToCanvasColor())
-	ctx.SetStrokeWidth(c.Options.LegendOptions.LineThickness)
-	ctx.MoveTo(0, 0)
-	ctx.LineTo(c.Options.LegendOptions.LineLength, 0)
-	ctx.Stroke()
+	length := c.Options.LegendOptions.LineLength
+	line := &canvas.Path{}
+	line.MoveTo(0, 0)
+	if seriesOpts.Curve == CurveSmooth {
+		// A gentle wave tells smooth series apart from straight ones
+		h := length / 4
+		line.CubeTo(length/3, h, 2*length/3, -h, length, 0)
+	} else {
+		line.LineTo(length, 0)
+	}
+	c.strokeSeriesPath(ctx, line, seriesOpts, c.Options.LegendOptions.LineThickness)
 	if c.Options.ShowPointMarkers {
 		center := canvas.Point{X: c.Options.LegendOptions.LineLength / 2, Y: 0}
 		c.drawSeriesPoint(ctx, center, seriesOpts)
@@ -413,25 +711,3 @@ func linmap(a float64, b float64, c float64, d float64, i float64) float64 {
 	p := (i - a) / (b - a)
 	return lerp(c, d, p)
 }
-
-func val2String(val float64) string {
-	if val < 1.0 {
-		return fmt.Sprintf("%.3f", val)
-	} else if val < 10.0 {
-		return fmt.Sprintf("%.2f", val)
-	} else if val < 100.0 {
-		return fmt.Sprintf("%.1f", val)
-	} else if val < 1000.0 {
-		return fmt.Sprintf("%.0f", val)
-	} else if val < 1000000.0 {
-		return fmt.Sprintf("%.0fk", val/1000.0)
-	} else if val < 1000000000.0 {
-		return fmt.Sprintf("%.0fM", val/1000000.0)
-	} else if val < 1000000000000.0 {
-		return fmt.Sprintf("%.0fG", val/1000000000.0)
-	} else if val < 1000000000000000.0 {
-		return fmt.Sprintf("%.0fT", val/1000000000000.0)
-	} else {
-		return fmt.Sprintf("%.0fP", val/1000000000000000.0)
-	}
-}
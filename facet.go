@@ -0,0 +1,191 @@
+package spider
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tdewolff/canvas"
+)
+
+// FacetOptions represents options for drawing the series as small multiples: a grid
+// of panels that each show one series or one group of series
+type FacetOptions struct {
+	By              FacetMode `json:"by" yaml:"by"`                               // What each panel shows (none, series or group)
+	Rows            int       `json:"rows" yaml:"rows"`                           // Number of panel rows (0 calculates it from the columns)
+	Columns         int       `json:"columns" yaml:"columns"`                     // Number of panel columns (0 calculates it from the rows)
+	Spacing         float64   `json:"spacing" yaml:"spacing"`                     // Space between the panels in millimeters
+	SharedScales    bool      `json:"shared_scales" yaml:"shared_scales"`         // Whether all panels use the axis ranges of all series instead of their own
+	ShowPanelTitles bool      `json:"show_panel_titles" yaml:"show_panel_titles"` // Whether to show the series or group name above each panel
+	PanelTitleStyle Font      `json:"panel_title_style" yaml:"panel_title_style"` // Panel title text style
+}
+
+// DefaultFacetOptions returns default facet options
+func DefaultFacetOptions() FacetOptions {
+	return FacetOptions{
+		By:              FacetNone,
+		Rows:            0,
+		Columns:         0,
+		Spacing:         DefaultFacetSpacing,
+		SharedScales:    true,
+		ShowPanelTitles: true,
+		PanelTitleStyle: Font{
+			Size:  DefaultPanelTitleFontSize,
+			Color: Color("#000000"),
+		},
+	}
+}
+
+// facetPanel is one panel of a small multiples chart
+type facetPanel struct {
+	title  string
+	series []int
+}
+
+// facetPanels returns the panels of a small multiples chart in reading order. Every
+// visible series gets a panel, or shares one with the other series of its group. The
+// reference series is added to every panel instead of getting its own.
+func (c *Chart) facetPanels() []facetPanel {
+	var panels []facetPanel
+	groups := make(map[string]int)
+	for i := range c.Data.Series {
+		series := &c.Data.Series[i]
+		if !series.IsVisible() || c.isReference(i) {
+			continue
+		}
+		key := series.Name
+		if c.Options.Facets.By == FacetGroup && series.Group != "" {
+			key = series.Group
+		}
+		if k, ok := groups[key]; ok && c.Options.Facets.By == FacetGroup {
+			panels[k].series = append(panels[k].series, i)
+			continue
+		}
+		groups[key] = len(panels)
+		panels = append(panels, facetPanel{title: key, series: []int{i}})
+	}
+	if ref := c.referenceIndex(); ref >= 0 {
+		for k := range panels {
+			panels[k].series = append(panels[k].series, ref)
+		}
+	}
+	return panels
+}
+
+// facetGrid returns the number of rows and columns of the panel grid. Missing counts
+// are calculated so that n panels fit, as close to a square grid as possible.
+func (c *Chart) facetGrid(n int) (int, int) {
+	rows, cols := c.Options.Facets.Rows, c.Options.Facets.Columns
+	switch {
+	case rows > 0 && cols > 0:
+	case cols > 0:
+		rows = (n + cols - 1) / cols
+	case rows > 0:
+		cols = (n + rows - 1) / rows
+	default:
+		cols = int(math.Ceil(math.Sqrt(float64(n))))
+		rows = (n + cols - 1) / cols
+	}
+	return max(rows, 1), max(cols, 1)
+}
+
+// validateFacets checks the facet options
+func (c *Chart) validateFacets() error {
+	facets := c.Options.Facets
+	switch facets.By {
+	case FacetNone, FacetSeries, FacetGroup:
+	default:
+		return &ValidationError{
+			Field:   "options.facets.by",
+			Message: fmt.Sprintf("unknown facet mode: %s", facets.By),
+		}
+	}
+	if facets.Rows < 0 || facets.Columns < 0 {
+		return &ValidationError{
+			Field:   "options.facets.rows",
+			Message: "rows and columns must not be negative",
+		}
+	}
+	if facets.Spacing < 0 {
+		return &ValidationError{
+			Field:   "options.facets.spacing",
+			Message: "spacing must not be negative",
+		}
+	}
+	if facets.By == FacetNone || facets.Rows == 0 || facets.Columns == 0 {
+		return nil
+	}
+	if n := len(c.facetPanels()); facets.Rows*facets.Columns < n {
+		return &ValidationError{
+			Field:   "options.facets.rows",
+			Message: fmt.Sprintf("%d rows and %d columns do not fit %d panels", facets.Rows, facets.Columns, n),
+		}
+	}
+	return nil
+}
+
+// panelSeries returns a copy of the series with index i with its palette color and
+// point marker resolved, so that it looks the same in a panel as in the legend
+func (c *Chart) panelSeries(i int) Series {
+	series := c.Data.Series[i]
+	series.Options = c.paletteOptions(i)
+	return series
+}
+
+// drawPanels draws one panel per series or group in a grid that fills the plot area.
+// Each panel is drawn as a chart of its own series that shares the options, axes and
+// fonts of the chart.
+func (c *Chart) drawPanels(ctx *canvas.Context) {
+	facets := c.Options.Facets
+	panels := c.facetPanels()
+	if len(panels) == 0 {
+		return
+	}
+	rows, cols := c.facetGrid(len(panels))
+	area := c.plotRect
+	cellW := (area.W() - float64(cols-1)*facets.Spacing) / float64(cols)
+	cellH := (area.H() - float64(rows-1)*facets.Spacing) / float64(rows)
+	titleFace := c.fonts["panel_title"]
+	titleHeight := 0.0
+	if facets.ShowPanelTitles {
+		titleHeight = titleFace.LineHeight() * smidge
+	}
+	for k, p := range panels {
+		row, col := k/cols, k%cols
+		cell := canvas.Rect{
+			X0: area.X0 + float64(col)*(cellW+facets.Spacing),
+			Y1: area.Y1 - float64(row)*(cellH+facets.Spacing),
+		}
+		cell.X1 = cell.X0 + cellW
+		cell.Y0 = cell.Y1 - cellH
+		panel := &Chart{
+			Options:  c.Options,
+			Data:     ChartData{Axes: c.Data.Axes},
+			pageRect: c.pageRect,
+			fonts:    c.fonts,
+		}
+		// The plot is fitted with its axis names in the cell below the title, and the
+		// two are centered in the cell together
+		radius, extent := panel.fitPlot(cellW, cellH-titleHeight, math.Min(cellW, cellH)/2)
+		center := cell.Center()
+		center.X -= extent.Center().X
+		center.Y -= extent.Center().Y + titleHeight/2
+		half := radius + c.Options.PlotOptions.Padding
+		panel.plotRect = canvas.Rect{
+			X0: center.X - half,
+			Y0: center.Y - half,
+			X1: center.X + half,
+			Y1: center.Y + half,
+		}
+		for _, i := range p.series {
+			panel.Data.Series = append(panel.Data.Series, c.panelSeries(i))
+		}
+		if facets.SharedScales {
+			panel.scaleSeries = c.Data.Series
+		}
+		panel.drawPlot(ctx)
+		if facets.ShowPanelTitles {
+			top := center.Y + extent.Y1 + titleHeight
+			ctx.DrawText(cell.Center().X, top-titleFace.Metrics().Ascent, canvas.NewTextLine(titleFace, p.title, canvas.Center))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package spider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+var facetSeries = seriesConfig(`
+    - {name: a1, group: g1, data: {a: 1, b: 2, c: 3}}
+    - {name: b, group: g2, data: {a: 2, b: 2, c: 2}}
+    - {name: a2, group: g1, data: {a: 3, b: 2, c: 1}}
+    - {name: hidden, group: g3, visible: false, data: {a: 1, b: 1, c: 1}}
+    - {name: ref, data: {a: 5, b: 5, c: 5}}
+`)
+
+func TestFacetPanels(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    string
+		wantTitles []string
+		wantSeries [][]int
+	}{
+		{"by series", "{facets: {by: series}}", []string{"a1", "b", "a2", "ref"}, [][]int{{0}, {1}, {2}, {4}}},
+		{"by group", "{facets: {by: group}}", []string{"g1", "g2", "ref"}, [][]int{{0, 2}, {1}, {4}}},
+		{"with reference", "{facets: {by: group}, reference: {series: ref}}", []string{"g1", "g2"}, [][]int{{0, 2, 4}, {1, 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewChartFromYAML([]byte("options: " + tt.options + facetSeries))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var titles []string
+			var series [][]int
+			for _, p := range c.facetPanels() {
+				titles = append(titles, p.title)
+				series = append(series, p.series)
+			}
+			if !slices.Equal(titles, tt.wantTitles) || !slices.EqualFunc(series, tt.wantSeries, slices.Equal) {
+				t.Errorf("panels %v with series %v, want %v with %v", titles, series, tt.wantTitles, tt.wantSeries)
+			}
+			drawChart(t, c)
+		})
+	}
+}
+
+func TestValidateFacets(t *testing.T) {
+	tests := []struct {
+		options string
+		wantErr string
+	}{
+		{"{facets: {by: series, rows: 2, columns: 2}}", ""},
+		{"{facets: {by: group, rows: 1, columns: 3}}", ""},
+		{"{facets: {by: axis}}", "unknown facet mode"},
+		{"{facets: {by: series, rows: -1}}", "must not be negative"},
+		{"{facets: {by: series, spacing: -2}}", "spacing must not be negative"},
+		{"{facets: {by: series, rows: 1, columns: 3}}", "1 rows and 3 columns do not fit 4 panels"},
+		{"{facets: {by: group, rows: 1, columns: 2}}", "1 rows and 2 columns do not fit 3 panels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.options, func(t *testing.T) {
+			_, err := NewChartFromYAML([]byte("options: " + tt.options + facetSeries))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package spider
+
+import (
+	"image/color"
+
+	"github.com/tdewolff/canvas"
+)
+
+// hasFill returns true if the series area is filled
+func (o SeriesOptions) hasFill() bool {
+	if o.FillStyle == FillStyleRadialGradient {
+		return o.FillOpacity > 0 || o.GradientCenterOpacity > 0
+	}
+	return o.FillOpacity > 0
+}
+
+// fillSeriesPath fills the area of a series with its fill style. Hatch patterns are
+// drawn as paths.
+func (c *Chart) fillSeriesPath(ctx *canvas.Context, p *canvas.Path, seriesOpts SeriesOptions) {
+	if !seriesOpts.hasFill() {
+		return
+	}
+	fillColor := seriesOpts.FillColor.ToCanvasColorWithOpacity(seriesOpts.FillOpacity)
+	ctx.Push()
+	ctx.SetStrokeColor(canvas.Transparent)
+	switch seriesOpts.FillStyle {
+	case FillStyleHatch:
+		ctx.SetFillColor(fillColor)
+		ctx.DrawPath(0, 0, c.hatchPath(p, seriesOpts))
+	case FillStyleRadialGradient:
+		center := c.plotRect.Center()
+		gradient := canvas.NewRadialGradient(center, 0, center, c.Radius())
+		centerColor := seriesOpts.GradientCenterColor
+		if centerColor == "" {
+			centerColor = seriesOpts.FillColor
+		}
+		gradient.Add(0, toRGBA(centerColor.ToCanvasColorWithOpacity(seriesOpts.GradientCenterOpacity)))
+		gradient.Add(1, toRGBA(fillColor))
+		ctx.SetFillGradient(gradient)
+		ctx.DrawPath(0, 0, p)
+	default:
+		ctx.SetFillColor(fillColor)
+		ctx.DrawPath(0, 0, p)
+	}
+	ctx.Pop()
+}
+
+// hatchPath returns the hatch pattern of a series clipped to the area p. The pattern is
+// rotated counter-clockwise by the hatch angle around the plot center.
+func (c *Chart) hatchPath(p *canvas.Path, seriesOpts SeriesOptions) *canvas.Path {
+	spacing := seriesOpts.HatchSpacing
+	thickness := seriesOpts.HatchThickness
+	center := c.plotRect.Center()
+	rotate := canvas.Identity.RotateAbout(seriesOpts.HatchAngle, center.X, center.Y)
+	// Tile the unrotated pattern over the area rotated the other way
+	clip := p.Copy().Transform(rotate.Inv())
+	var hatch *canvas.Path
+	switch seriesOpts.HatchPattern {
+	case HatchPatternCross:
+		// Tiling a single crossed pattern produces self-intersecting outlines, so the
+		// two directions are tiled separately
+		hatch = canvas.NewLineHatch(canvas.Black, 45, spacing, thickness).Tile(clip)
+		hatch = hatch.Append(canvas.NewLineHatch(canvas.Black, 135, spacing, thickness).Tile(clip))
+	case HatchPatternDots:
+		hatch = canvas.NewShapeHatch(canvas.Black, canvas.Circle(thickness), spacing, 0).Tile(clip)
+	default:
+		hatch = canvas.NewLineHatch(canvas.Black, 45, spacing, thickness).Tile(clip)
+	}
+	return hatch.Transform(rotate)
+}
+
+// toRGBA converts a color to premultiplied RGBA as used by canvas gradients
+func toRGBA(col color.Color) color.RGBA {
+	return color.RGBAModel.Convert(col).(color.RGBA)
+}
@@ -0,0 +1,35 @@
+package spider
+
+import "testing"
+
+func TestGradientFillRespectsNoFill(t *testing.T) {
+	c, err := NewChartFromYAML([]byte(seriesConfig(`
+    - name: base
+      data: {a: 5, b: 5, c: 5}
+      options: {fill_style: radial_gradient, fill_opacity: 0.5, gradient_center_opacity: 0.8}
+    - name: outline
+      data: {a: 4, b: 4, c: 4}
+      options: {fill_style: radial_gradient, fill_opacity: 0.5, gradient_center_opacity: 0.8, outline_only: true}
+    - name: gradient center only
+      data: {a: 3, b: 3, c: 3}
+      options: {fill_style: radial_gradient, gradient_center_opacity: 0.8}
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Options.Reference.Series = "base"
+	tests := []struct {
+		index int
+		want  bool
+	}{
+		{0, false}, // the reference series is not filled
+		{1, false}, // outline_only series are not filled
+		{2, true},  // a gradient may fade in from a transparent fill color
+	}
+	for _, tt := range tests {
+		name := c.Data.Series[tt.index].Name
+		if got := c.seriesOptions(tt.index).hasFill(); got != tt.want {
+			t.Errorf("series %s hasFill() = %v, want %v", name, got, tt.want)
+		}
+	}
+}
@@ -14,6 +14,23 @@ type Font struct {
 	Color    Color   `json:"color" yaml:"color"` // Font color
 }
 
+// merge returns a copy of f with the non-zero fields of o applied
+func (f Font) merge(o Font) Font {
+	if o.FontName != "" {
+		f.FontName = o.FontName
+	}
+	if o.FontPath != "" {
+		f.FontPath = o.FontPath
+	}
+	if o.Size != 0 {
+		f.Size = o.Size
+	}
+	if o.Color != "" {
+		f.Color = o.Color
+	}
+	return f
+}
+
 func (f *Font) loadFontFace(fontName string, fontPath string) (*canvas.FontFace, error) {
 	if f.FontPath == "" && f.FontName == "" {
 		// Try system fonts first (more reliable, avoids CFF errors)
@@ -0,0 +1,190 @@
+package spider
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// floatVerb matches a printf verb for a float64: flags, width and precision, followed
+// by one of the float verbs
+var floatVerb = regexp.MustCompile(`^%[-+# 0]*[0-9]*(\.[0-9]*)?[eEfFgG]`)
+
+// ValueFormat describes how axis values are converted to tick and value labels
+type ValueFormat struct {
+	Mode              FormatMode `json:"mode" yaml:"mode"`                                       // Formatting mode (auto, plain, si, iec, percent or duration)
+	Format            string     `json:"format,omitempty" yaml:"format,omitempty"`               // printf-style format for the scaled number, e.g. "%.1f"
+	SignificantDigits int        `json:"significant_digits" yaml:"significant_digits"`           // Number of significant digits when no format is given
+	DurationUnit      string     `json:"duration_unit,omitempty" yaml:"duration_unit,omitempty"` // Unit of the raw values in duration mode (ns, us, ms or s)
+	Prefix            string     `json:"prefix,omitempty" yaml:"prefix,omitempty"`               // Text placed before the number, e.g. "$"
+	Suffix            string     `json:"suffix,omitempty" yaml:"suffix,omitempty"`               // Unit text placed after the number, e.g. " ms"
+}
+
+// DefaultValueFormat returns the default value format
+func DefaultValueFormat() ValueFormat {
+	return ValueFormat{
+		Mode:              FormatModeAuto,
+		SignificantDigits: DefaultSignificantDigits,
+	}
+}
+
+// merge returns a copy of f with the non-zero fields of o applied
+func (f ValueFormat) merge(o ValueFormat) ValueFormat {
+	if o.Mode != "" {
+		f.Mode = o.Mode
+	}
+	if o.Format != "" {
+		f.Format = o.Format
+	}
+	if o.SignificantDigits != 0 {
+		f.SignificantDigits = o.SignificantDigits
+	}
+	if o.DurationUnit != "" {
+		f.DurationUnit = o.DurationUnit
+	}
+	if o.Prefix != "" {
+		f.Prefix = o.Prefix
+	}
+	if o.Suffix != "" {
+		f.Suffix = o.Suffix
+	}
+	return f
+}
+
+// validate checks that the mode and duration unit are known, and that the format
+// prints the number with a single float verb
+func (f ValueFormat) validate() error {
+	switch f.Mode {
+	case "", FormatModeAuto, FormatModePlain, FormatModeSI, FormatModeIEC, FormatModePercent, FormatModeDuration:
+	default:
+		return fmt.Errorf("unknown format mode: %s", f.Mode)
+	}
+	if _, ok := durationUnits[f.DurationUnit]; f.DurationUnit != "" && !ok {
+		return fmt.Errorf("unknown duration unit: %s", f.DurationUnit)
+	}
+	if f.Format != "" && !isFloatFormat(f.Format) {
+		return fmt.Errorf("format %q must contain exactly one float verb such as %%.1f", f.Format)
+	}
+	return nil
+}
+
+// isFloatFormat returns true if a printf format has exactly one verb, and that verb
+// formats a float64. Escaped percent signs are not verbs.
+func isFloatFormat(format string) bool {
+	verbs := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if strings.HasPrefix(format[i:], "%%") {
+			i++
+			continue
+		}
+		verb := floatVerb.FindString(format[i:])
+		if verb == "" {
+			return false
+		}
+		verbs++
+		i += len(verb) - 1
+	}
+	return verbs == 1
+}
+
+// durationUnits maps duration unit names to their length in seconds
+var durationUnits = map[string]float64{
+	"ns": 1e-9,
+	"us": 1e-6,
+	"µs": 1e-6,
+	"ms": 1e-3,
+	"s":  1,
+}
+
+// prefix is a scaling factor and the symbol appended to values scaled by it
+type prefix struct {
+	factor float64
+	symbol string
+}
+
+var (
+	autoPrefixes = []prefix{{1e15, "P"}, {1e12, "T"}, {1e9, "G"}, {1e6, "M"}, {1e3, "k"}}
+	siPrefixes   = []prefix{{1e15, "P"}, {1e12, "T"}, {1e9, "G"}, {1e6, "M"}, {1e3, "k"}, {1, ""}, {1e-3, "m"}, {1e-6, "µ"}, {1e-9, "n"}, {1e-12, "p"}}
+	iecPrefixes  = []prefix{{1 << 50, "PiB"}, {1 << 40, "TiB"}, {1 << 30, "GiB"}, {1 << 20, "MiB"}, {1 << 10, "KiB"}, {1, "B"}}
+	timePrefixes = []prefix{{1, "s"}, {1e-3, "ms"}, {1e-6, "µs"}, {1e-9, "ns"}}
+)
+
+// FormatValue converts a value to a label
+func (f ValueFormat) FormatValue(val float64) string {
+	var unit string
+	switch f.Mode {
+	case FormatModePlain:
+	case FormatModeSI:
+		val, unit = applyPrefix(val, siPrefixes)
+	case FormatModeIEC:
+		val, unit = applyPrefix(val, iecPrefixes)
+	case FormatModePercent:
+		val, unit = val*100, "%"
+	case FormatModeDuration:
+		if factor, ok := durationUnits[f.DurationUnit]; ok {
+			val *= factor
+		}
+		val, unit = applyPrefix(val, timePrefixes)
+	default:
+		val, unit = applyPrefix(val, autoPrefixes)
+	}
+	var number string
+	if f.Format != "" {
+		number = fmt.Sprintf(f.Format, val)
+	} else {
+		digits := f.SignificantDigits
+		if digits <= 0 {
+			digits = DefaultSignificantDigits
+		}
+		number = formatSignificant(val, digits)
+	}
+	return f.Prefix + number + unit + f.Suffix
+}
+
+// applyPrefix scales val by the largest prefix not greater than its magnitude.
+// Values smaller than every prefix are scaled by the last one, unless all prefixes
+// are larger than one, in which case the value is returned unscaled.
+func applyPrefix(val float64, prefixes []prefix) (float64, string) {
+	abs := math.Abs(val)
+	if abs == 0 {
+		for _, p := range prefixes {
+			if p.factor == 1 {
+				return val, p.symbol
+			}
+		}
+		return val, ""
+	}
+	for _, p := range prefixes {
+		if abs >= p.factor*(1-tickEpsilon) {
+			return val / p.factor, p.symbol
+		}
+	}
+	p := prefixes[len(prefixes)-1]
+	if p.factor > 1 {
+		return val, ""
+	}
+	return val / p.factor, p.symbol
+}
+
+// formatSignificant formats val with the given number of significant digits,
+// without an exponent and without trailing zeros
+func formatSignificant(val float64, digits int) string {
+	if val == 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	magnitude := int(math.Floor(math.Log10(math.Abs(val))))
+	decimals := max(digits-1-magnitude, 0)
+	s := strconv.FormatFloat(val, 'f', decimals, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package spider
+
+import "testing"
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ValueFormat
+		value  float64
+		want   string
+	}{
+		{"auto small", ValueFormat{Mode: FormatModeAuto}, 42, "42"},
+		{"auto thousands", ValueFormat{Mode: FormatModeAuto}, 1500, "1.5k"},
+		{"auto millions", ValueFormat{Mode: FormatModeAuto}, 2500000, "2.5M"},
+		{"auto negative", ValueFormat{Mode: FormatModeAuto}, -2000, "-2k"},
+		{"auto zero", ValueFormat{Mode: FormatModeAuto}, 0, "0"},
+		{"auto significant digits", ValueFormat{Mode: FormatModeAuto, SignificantDigits: 2}, 12345, "12k"},
+		{"plain", ValueFormat{Mode: FormatModePlain}, 12345, "12345"},
+		{"plain fraction", ValueFormat{Mode: FormatModePlain}, 0.12345, "0.123"},
+		{"printf format", ValueFormat{Mode: FormatModePlain, Format: "%.2f"}, 3, "3.00"},
+		{"si milli", ValueFormat{Mode: FormatModeSI}, 0.0025, "2.5m"},
+		{"si unit", ValueFormat{Mode: FormatModeSI}, 7, "7"},
+		{"iec", ValueFormat{Mode: FormatModeIEC}, 1536, "1.5KiB"},
+		{"iec bytes", ValueFormat{Mode: FormatModeIEC}, 512, "512B"},
+		{"percent", ValueFormat{Mode: FormatModePercent}, 0.25, "25%"},
+		{"duration seconds", ValueFormat{Mode: FormatModeDuration, DurationUnit: "ms"}, 1500, "1.5s"},
+		{"duration micro", ValueFormat{Mode: FormatModeDuration}, 0.00025, "250µs"},
+		{"prefix and suffix", ValueFormat{Mode: FormatModePlain, Prefix: "$", Suffix: " USD"}, 120, "$120 USD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.FormatValue(tt.value); got != tt.want {
+				t.Errorf("FormatValue(%g) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueFormatValidate(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"", false},
+		{"%.1f", false},
+		{"%g ms", false},
+		{"%-08.3e", false},
+		{"%5.1f%%", false},
+		{"%d", true},
+		{"%s", true},
+		{"%*f", true},
+		{"%.1f / %.1f", true},
+		{"100%%", true},
+		{"%", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := ValueFormat{Mode: FormatModePlain, Format: tt.format}.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate(%q) error = %v, want error %v", tt.format, err, tt.wantErr)
+			}
+		})
+	}
+}
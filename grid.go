@@ -0,0 +1,77 @@
+package spider
+
+import (
+	"math"
+	"slices"
+)
+
+// GridOptions represents options for the concentric grid rings of the plot
+type GridOptions struct {
+	Show           bool      `json:"show" yaml:"show"`                                 // Whether to draw grid rings at the major tick positions
+	ShowMinor      bool      `json:"show_minor" yaml:"show_minor"`                     // Whether to draw grid rings at the minor tick positions
+	Color          Color     `json:"color" yaml:"color"`                               // Color of the major grid rings
+	Thickness      float64   `json:"thickness" yaml:"thickness"`                       // Thickness of the major grid rings in millimeters
+	MinorColor     Color     `json:"minor_color" yaml:"minor_color"`                   // Color of the minor grid rings
+	MinorThickness float64   `json:"minor_thickness" yaml:"minor_thickness"`           // Thickness of the minor grid rings in millimeters
+	Dashes         []float64 `json:"dashes,omitempty" yaml:"dashes,omitempty"`         // Dash pattern of the grid rings in millimeters (empty means solid)
+	BandColor      Color     `json:"band_color,omitempty" yaml:"band_color,omitempty"` // Fill color of alternating bands between major rings (empty means no bands)
+	BandOpacity    float64   `json:"band_opacity" yaml:"band_opacity"`                 // Opacity of the band fill
+}
+
+// DefaultGridOptions returns default grid options
+func DefaultGridOptions() GridOptions {
+	return GridOptions{
+		Show:           false,
+		ShowMinor:      false,
+		Color:          Color("#bbbbbb"),
+		Thickness:      DefaultGridThickness,
+		MinorColor:     Color("#e0e0e0"),
+		MinorThickness: DefaultMinorGridThickness,
+		BandColor:      "",
+		BandOpacity:    DefaultGridBandOpacity,
+	}
+}
+
+// gridFractions returns the radius fractions of the major and minor grid rings. A ring
+// is drawn only where every axis has a tick, so that each ring passes through a tick
+// label on every axis.
+func (c *Chart) gridFractions() ([]float64, []float64) {
+	seriesData := c.scaleData()
+	var major, minor []float64
+	for i := range c.Data.Axes {
+		axis := &c.Data.Axes[i]
+		opts := c.axisOptions(axis)
+		scale := axis.scale(seriesData, opts)
+		ticks := scale.majorTicks(opts.MajorTicks)
+		axisMajor := interiorFractions(scale, ticks)
+		axisMinor := interiorFractions(scale, scale.minorTicks(ticks, opts.MinorTicks))
+		if i == 0 {
+			major, minor = axisMajor, axisMinor
+			continue
+		}
+		major = sharedFractions(major, axisMajor)
+		minor = sharedFractions(minor, axisMinor)
+	}
+	return major, minor
+}
+
+// interiorFractions returns the sorted fractions of the values that lie inside the axis
+func interiorFractions(scale axisScale, values []float64) []float64 {
+	var fractions []float64
+	for _, val := range values {
+		if f := scale.fraction(val); isInterior(f) {
+			fractions = append(fractions, f)
+		}
+	}
+	slices.Sort(fractions)
+	return fractions
+}
+
+// sharedFractions returns the fractions of a that are also in b
+func sharedFractions(a []float64, b []float64) []float64 {
+	return slices.DeleteFunc(a, func(x float64) bool {
+		return !slices.ContainsFunc(b, func(y float64) bool {
+			return math.Abs(x-y) < tickEpsilon
+		})
+	})
+}
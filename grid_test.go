@@ -0,0 +1,42 @@
+package spider
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestGridFractions(t *testing.T) {
+	type axis struct {
+		max        float64
+		majorTicks int // 0 means the global 4 major ticks
+	}
+	tests := []struct {
+		name      string
+		axes      []axis
+		wantMajor []float64
+	}{
+		{"equal axes", []axis{{10, 0}, {10, 0}, {10, 0}}, []float64{0.2, 0.4, 0.6, 0.8}},
+		{"equal fractions", []axis{{10, 0}, {5, 0}, {100, 0}}, []float64{0.2, 0.4, 0.6, 0.8}},
+		{"tick missing on one axis", []axis{{10, 1}, {10, 9}, {10, 0}}, nil},
+		{"middle tick shared", []axis{{10, 1}, {10, 9}, {20, 3}}, []float64{0.5}},
+		{"no shared ticks", []axis{{8, 0}, {10, 0}, {10, 0}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChart()
+			c.Options.AxisOptions.MajorTicks = 4
+			for i, a := range tt.axes {
+				axis := Axis{Name: string(rune('a' + i)), Max: a.max}
+				if a.majorTicks > 0 {
+					axis.Options = &AxisOptionsOverride{MajorTicks: &a.majorTicks}
+				}
+				c.Data.Axes = append(c.Data.Axes, axis)
+			}
+			major, _ := c.gridFractions()
+			if !slices.EqualFunc(major, tt.wantMajor, func(x, y float64) bool { return math.Abs(x-y) < 1e-9 }) {
+				t.Errorf("major rings at %v, want %v", major, tt.wantMajor)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package spider
+
+import (
+	"fmt"
+	"maps"
+	"math"
+
+	"github.com/tdewolff/canvas"
+)
+
+// Snapshot represents the values of a series at one point in time
+type Snapshot struct {
+	Time string             `json:"time" yaml:"time"` // Timestamp or release name of the snapshot
+	Data map[string]float64 `json:"data" yaml:"data"` // Data values keyed by axis name
+}
+
+// HistoryOptions represents options for drawing the history snapshots of series
+type HistoryOptions struct {
+	MinOpacity float64 `json:"min_opacity" yaml:"min_opacity"` // Opacity of the oldest snapshot outline, increasing towards the latest snapshot
+	Arrows     bool    `json:"arrows" yaml:"arrows"`           // Whether to draw arrows along each axis from the previous to the latest value
+	ArrowSize  float64 `json:"arrow_size" yaml:"arrow_size"`   // Length of the arrow heads in millimeters
+}
+
+// DefaultHistoryOptions returns default history options
+func DefaultHistoryOptions() HistoryOptions {
+	return HistoryOptions{
+		MinOpacity: DefaultHistoryMinOpacity,
+		Arrows:     false,
+		ArrowSize:  DefaultHistoryArrowSize,
+	}
+}
+
+// applyHistory adds the values of the latest snapshot of a series with history to its
+// plotted values
+func (s *Series) applyHistory() {
+	if len(s.History) == 0 {
+		return
+	}
+	maps.Copy(s.values, s.History[len(s.History)-1].Data)
+}
+
+// validateHistory checks that the snapshots only refer to the provided axis names.
+// Snapshots may leave out axes.
+func (s *Series) validateHistory(axisNames []string) error {
+	for _, snapshot := range s.History {
+		if err := (&Series{values: snapshot.Data}).validateData(axisNames, true); err != nil {
+			return fmt.Errorf("snapshot %s: %w", snapshot.Time, err)
+		}
+	}
+	return nil
+}
+
+// historyRange returns the times of the oldest and the latest snapshot of a series,
+// e.g. "v1.0–v1.3". The result is false if the series has fewer than two snapshots.
+func (s *Series) historyRange() (string, bool) {
+	if len(s.History) < 2 {
+		return "", false
+	}
+	return fmt.Sprintf("%s–%s", s.History[0].Time, s.History[len(s.History)-1].Time), true
+}
+
+// drawHistory draws the snapshots of a series before the latest one as outlines that
+// fade out with their age
+func (c *Chart) drawHistory(ctx *canvas.Context, i int, seriesOpts SeriesOptions, scales []axisScale) {
+	history := c.Data.Series[i].History
+	n := len(history)
+	for k := 0; k < n-1; k++ {
+		// The oldest snapshot has the minimum opacity and the latest full opacity
+		opacity := lerp(c.Options.History.MinOpacity, 1, float64(k)/float64(n-1))
+		points, present := c.dataPoints(history[k].Data, scales)
+		c.strokeSeriesPath(ctx, c.seriesPath(points, present, seriesOpts), seriesOpts.dimmed(0, opacity), seriesOpts.LineThickness)
+	}
+}
+
+// drawHistoryArrows draws an arrow along each axis from the value of the previous
+// snapshot of a series to its latest value. The arrow ends at the point marker.
+func (c *Chart) drawHistoryArrows(ctx *canvas.Context, i int, seriesOpts SeriesOptions, scales []axisScale) {
+	history := c.Data.Series[i].History
+	if !c.Options.History.Arrows || len(history) < 2 {
+		return
+	}
+	radius := c.Radius()
+	col := seriesOpts.LineColor.ToCanvasColor()
+	ctx.Push()
+	ctx.SetStrokeWidth(seriesOpts.LineThickness)
+	for j, axis := range c.Data.Axes {
+		prev, prevOk := history[len(history)-2].Data[axis.Name]
+		value, ok := c.Data.Series[i].value(axis.Name)
+		if !prevOk || !ok {
+			continue
+		}
+		r0 := scales[j].radius(prev, radius)
+		r1 := scales[j].radius(value, radius)
+		if r1 == r0 {
+			continue
+		}
+		dir := math.Copysign(1, r1-r0)
+		if c.Options.ShowPointMarkers {
+			r1 -= dir * seriesOpts.PointSize / 2
+		}
+		if dir*(r1-r0) <= 0 {
+			// The change is hidden under the point marker
+			continue
+		}
+		head := math.Min(c.Options.History.ArrowSize, dir*(r1-r0))
+		base := c.axisPoint(j, r1-dir*head)
+		// Shaft up to the base of the arrow head
+		start := c.axisPoint(j, r0)
+		shaft := &canvas.Path{}
+		shaft.MoveTo(start.X, start.Y)
+		shaft.LineTo(base.X, base.Y)
+		ctx.SetFillColor(canvas.Transparent)
+		ctx.SetStrokeColor(col)
+		ctx.DrawPath(0, 0, shaft)
+		// Arrow head pointing along the axis towards the latest value
+		theta := c.axisAngle(j)
+		normal := canvas.Point{X: -math.Sin(theta), Y: math.Cos(theta)}.Mul(head / 2)
+		tip := c.axisPoint(j, r1)
+		arrow := &canvas.Path{}
+		arrow.MoveTo(tip.X, tip.Y)
+		arrow.LineTo(base.X+normal.X, base.Y+normal.Y)
+		arrow.LineTo(base.X-normal.X, base.Y-normal.Y)
+		arrow.Close()
+		ctx.SetFillColor(col)
+		ctx.SetStrokeColor(canvas.Transparent)
+		ctx.DrawPath(0, 0, arrow)
+	}
+	ctx.Pop()
+}
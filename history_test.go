@@ -0,0 +1,68 @@
+package spider
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var historyConfig = seriesConfig(`
+    - name: s
+      data: {b: 5, c: 5}
+      history:
+        - {time: v1, data: {a: 1}}
+`)
+
+func TestHistoryRedraw(t *testing.T) {
+	c, err := NewChartFromYAML([]byte(historyConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drawChart(t, c)
+	s := &c.Data.Series[0]
+	if got, _ := s.value("a"); got != 1 {
+		t.Errorf("plotted a = %g, want 1", got)
+	}
+	if _, ok := s.Data["a"]; ok {
+		t.Errorf("the latest snapshot was copied into the data: %v", s.Data)
+	}
+	s.History = append(s.History, Snapshot{Time: "v2", Data: map[string]float64{"a": 2}})
+	drawChart(t, c)
+	if got, _ := s.value("a"); got != 2 {
+		t.Errorf("plotted a after appending a snapshot = %g, want 2", got)
+	}
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	c, err := NewChartFromYAML([]byte(historyConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drawChart(t, c)
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewChartFromYAML(data); err != nil {
+		t.Errorf("loading a marshalled chart failed: %v", err)
+	}
+}
+
+func TestHistoryConflicts(t *testing.T) {
+	tests := []struct {
+		name   string
+		series string
+	}{
+		{"data", "{name: s, data: {a: 3, b: 5, c: 5}, history: [{time: v1, data: {a: 1}}]}"},
+		{"samples", "{name: s, data: {b: 5, c: 5}, samples: {a: [1, 2]}, history: [{time: v1, data: {a: 1}}]}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChartFromYAML([]byte(seriesConfig("    - " + tt.series + "\n")))
+			if err == nil || !strings.Contains(err.Error(), "both history and data or samples") {
+				t.Errorf("expected a history conflict, got %v", err)
+			}
+		})
+	}
+}
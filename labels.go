@@ -0,0 +1,200 @@
+package spider
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/tdewolff/canvas"
+)
+
+// valueLabelAttempts is the number of times a value label is pushed outward to avoid
+// overlapping the labels placed before it
+const valueLabelAttempts = 8
+
+// ValueLabelOptions represents options for the labels drawn next to the series values
+type ValueLabelOptions struct {
+	Mode   ValueLabelMode `json:"mode" yaml:"mode"`     // Which values are labeled (none, all, max, min, extremes or highlighted)
+	Style  Font           `json:"style" yaml:"style"`   // Label text style (an empty color uses the series line color)
+	Offset float64        `json:"offset" yaml:"offset"` // Distance between the point marker and the label in millimeters
+}
+
+// DefaultValueLabelOptions returns default value label options
+func DefaultValueLabelOptions() ValueLabelOptions {
+	return ValueLabelOptions{
+		Mode: ValueLabelNone,
+		Style: Font{
+			Size: DefaultValueLabelFontSize,
+		},
+		Offset: DefaultValueLabelOffset,
+	}
+}
+
+// validate returns an error if the value label mode is unknown
+func (m ValueLabelMode) validate() error {
+	switch m {
+	case ValueLabelNone, ValueLabelAll, ValueLabelMax, ValueLabelMin, ValueLabelExtremes, ValueLabelHighlighted:
+		return nil
+	default:
+		return fmt.Errorf("unknown value label mode: %s", m)
+	}
+}
+
+// valueLabel is a value label placed on the chart
+type valueLabel struct {
+	text string
+	face *canvas.FontFace
+	rect canvas.Rect
+}
+
+// valueLabelMode returns the value label mode of the series with the given index
+func (c *Chart) valueLabelMode(i int) ValueLabelMode {
+	if mode := c.Data.Series[i].Options.ValueLabels; mode != "" {
+		return mode
+	}
+	return c.Options.ValueLabels.Mode
+}
+
+// isLabeled returns true if the value of the series with the given index on the axis
+// with index j gets a label
+func (c *Chart) isLabeled(i int, j int) bool {
+	axisName := c.Data.Axes[j].Name
+	value, ok := c.Data.Series[i].value(axisName)
+	if !ok {
+		return false
+	}
+	isExtreme := func(better func(a, b float64) bool) bool {
+		for k := range c.Data.Series {
+			if !c.Data.Series[k].IsVisible() {
+				continue
+			}
+			if other, ok := c.Data.Series[k].value(axisName); ok && better(other, value) {
+				return false
+			}
+		}
+		return true
+	}
+	isMax := func() bool { return isExtreme(func(a, b float64) bool { return a > b }) }
+	isMin := func() bool { return isExtreme(func(a, b float64) bool { return a < b }) }
+	switch c.valueLabelMode(i) {
+	case ValueLabelAll:
+		return true
+	case ValueLabelMax:
+		return isMax()
+	case ValueLabelMin:
+		return isMin()
+	case ValueLabelExtremes:
+		return isMax() || isMin()
+	case ValueLabelHighlighted:
+		return c.isHighlighted(i)
+	default:
+		return false
+	}
+}
+
+// pointLabel returns the label text of the value of the series with index i on the
+// axis with index j: the formatted value, its percent delta to the reference series,
+// or both. The second result is false if the point has no label.
+func (c *Chart) pointLabel(i int, j int) (string, bool) {
+	var text string
+	if c.isLabeled(i, j) {
+		axis := &c.Data.Axes[j]
+		text = c.axisOptions(axis).TickLabelFormat.FormatValue(c.Data.Series[i].values[axis.Name])
+	}
+	if c.Options.Reference.DeltaLabels == DeltaLabelPoints {
+		if d, _, ok := c.delta(i, j); ok && !math.IsInf(d, 0) && !math.IsNaN(d) {
+			if text == "" {
+				text = formatDelta(d)
+			} else {
+				text = fmt.Sprintf("%s (%s)", text, formatDelta(d))
+			}
+		}
+	}
+	return text, text != ""
+}
+
+// drawValueLabels draws the labels of the series values. Each label is placed
+// radially outside its point and pushed further out while it overlaps a point marker
+// or a label that was placed before it. A label that no push clears stays next to its
+// point.
+func (c *Chart) drawValueLabels(ctx *canvas.Context) {
+	scales := c.axisScales()
+	radius := c.Radius()
+	opts := c.Options.ValueLabels
+	// Point markers are obstacles as well, so that labels do not hide other points
+	var obstacles []canvas.Rect
+	for _, i := range c.seriesOrder() {
+		size := c.seriesOptions(i).PointSize
+		for j, axis := range c.Data.Axes {
+			if value, ok := c.Data.Series[i].value(axis.Name); ok {
+				p := c.axisPoint(j, scales[j].radius(value, radius))
+				obstacles = append(obstacles, canvas.Rect{X0: p.X - size/2, Y0: p.Y - size/2, X1: p.X + size/2, Y1: p.Y + size/2})
+			}
+		}
+	}
+	var placed []valueLabel
+	for i := range c.Data.Series {
+		if !c.Data.Series[i].IsVisible() {
+			continue
+		}
+		seriesOpts := c.seriesOptions(i)
+		face := c.valueLabelFace(seriesOpts)
+		metrics := face.Metrics()
+		height := metrics.Ascent + metrics.Descent
+		for j := range c.Data.Axes {
+			text, ok := c.pointLabel(i, j)
+			if !ok {
+				continue
+			}
+			axis := &c.Data.Axes[j]
+			value := c.Data.Series[i].values[axis.Name]
+			width := face.TextWidth(text)
+			// Distance from the center of the label to its edge along the axis
+			theta := c.axisAngle(j)
+			cos, sin := math.Cos(theta), math.Sin(theta)
+			edge := math.Min(width/2/math.Max(math.Abs(cos), 1e-9), height/2/math.Max(math.Abs(sin), 1e-9))
+			r := scales[j].radius(value, radius) + seriesOpts.PointSize/2 + opts.Offset + edge
+			rectAt := func(nudge float64) canvas.Rect {
+				center := c.axisPoint(j, r+nudge)
+				return canvas.Rect{
+					X0: center.X - width/2,
+					Y0: center.Y - height/2,
+					X1: center.X + width/2,
+					Y1: center.Y + height/2,
+				}
+			}
+			nudge, _ := nudgeOutward(height, valueLabelAttempts, func(nudge float64) bool {
+				return slices.ContainsFunc(obstacles, rectAt(nudge).Overlaps)
+			})
+			rect := rectAt(nudge)
+			placed = append(placed, valueLabel{text: text, face: face, rect: rect})
+			obstacles = append(obstacles, rect)
+		}
+	}
+	for _, l := range placed {
+		metrics := l.face.Metrics()
+		ctx.DrawText(l.rect.X0, l.rect.Y1-metrics.Ascent, canvas.NewTextLine(l.face, l.text, canvas.Left))
+	}
+}
+
+// nudgeOutward returns the smallest multiple of step, up to the given number of steps,
+// by which a label has to be pushed outward so that it no longer collides. The result
+// is false if no push clears the label.
+func nudgeOutward(step float64, attempts int, collides func(nudge float64) bool) (float64, bool) {
+	for attempt := 0; attempt <= attempts; attempt++ {
+		if nudge := float64(attempt) * step; !collides(nudge) {
+			return nudge, true
+		}
+	}
+	return 0, false
+}
+
+// valueLabelFace returns the font face of the value labels of a series, in the
+// series line color unless the label style sets a color
+func (c *Chart) valueLabelFace(seriesOpts SeriesOptions) *canvas.FontFace {
+	face := *c.fonts["value_label"]
+	if c.Options.ValueLabels.Style.Color == "" {
+		face.Fill = canvas.Paint{Color: toRGBA(seriesOpts.LineColor.ToCanvasColor())}
+	}
+	return &face
+}
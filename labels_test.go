@@ -0,0 +1,25 @@
+package spider
+
+import "testing"
+
+func TestNudgeOutward(t *testing.T) {
+	tests := []struct {
+		name      string
+		blocked   float64 // labels collide while pushed by less than this
+		wantNudge float64
+		wantOk    bool
+	}{
+		{"no collision", 0, 0, true},
+		{"one step", 1, 2, true},
+		{"last step", 7, 8, true},
+		{"no push clears", 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nudge, ok := nudgeOutward(2, 4, func(nudge float64) bool { return nudge < tt.blocked })
+			if nudge != tt.wantNudge || ok != tt.wantOk {
+				t.Errorf("nudgeOutward() = %g, %v, want %g, %v", nudge, ok, tt.wantNudge, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package spider
+
+import (
+	"math"
+
+	"github.com/tdewolff/canvas"
+)
+
+// layoutIterations is the number of bisection steps used to find the largest plot
+// radius that fits
+const layoutIterations = 40
+
+// layoutBand is a full-width band of the page stacked above or below the plot
+type layoutBand struct {
+	rect   *canvas.Rect // Rectangle of the band, set by the layout
+	height float64      // Height of the band in millimeters
+	gap    float64      // Space between the band and the next band towards the plot in millimeters
+}
+
+// calcRects lays out the chart on the page. The title, subtitle, legend, table and axis
+// names are measured with the loaded fonts. The title, subtitle, a bottom table and a
+// top or bottom legend are stacked above and below the plot, and a right table and a
+// left or right legend are placed beside it. The plot gets the largest radius, up to
+// the plot scale, at which it fits with its axis names in the remaining room, and the
+// whole is centered on the page. A legend in a corner takes no room and is placed in
+// the corner of the plot row.
+func (c *Chart) calcRects() {
+	w := c.Width()
+	h := c.Height()
+	margin := c.Options.PlotOptions.Margin
+
+	c.pageRect = canvas.Rect{
+		X0: c.Options.PageMargin,
+		Y0: c.Options.PageMargin,
+		X1: w - c.Options.PageMargin,
+		Y1: h - c.Options.PageMargin,
+	}
+	page := c.pageRect
+	c.titleRect = canvas.Rect{}
+	c.subtitleRect = canvas.Rect{}
+	c.legendRect = canvas.Rect{}
+	c.tableRect = canvas.Rect{}
+
+	var above, below []layoutBand
+	if c.Options.Title != "" {
+		text := canvas.NewTextBox(c.fonts["title"], c.Options.Title, page.W(), 0, canvas.Center, canvas.Top, nil)
+		above = append(above, layoutBand{&c.titleRect, textHeight(text), c.Options.TitleMargin})
+	}
+	if c.Options.Subtitle != "" {
+		text := canvas.NewTextBox(c.fonts["subtitle"], c.Options.Subtitle, page.W(), 0, canvas.Center, canvas.Top, nil)
+		above = append(above, layoutBand{&c.subtitleRect, textHeight(text), c.Options.SubtitleMargin})
+	}
+	side := 0.0
+	tablePlacement := c.Options.Table.Placement
+	tableWidth, tableHeight := 0.0, 0.0
+	showTable := c.hasTable()
+	if showTable {
+		table := c.seriesTable()
+		tableWidth, tableHeight = table.size()
+		switch tablePlacement {
+		case TablePlacementBottom:
+			below = append(below, layoutBand{&c.tableRect, tableHeight, margin})
+		case TablePlacementRight:
+			side += tableWidth + margin
+		}
+	}
+	placement := c.Options.LegendOptions.Placement
+	legendWidth, legendHeight := 0.0, 0.0
+	showLegend := c.hasLegend()
+	legendBeside := showLegend && (placement == LegendPlacementLeft || placement == LegendPlacementRight)
+	if showLegend {
+		legendWidth, legendHeight = c.legendSize()
+		switch placement {
+		case LegendPlacementTop:
+			above = append(above, layoutBand{&c.legendRect, legendHeight, margin})
+		case LegendPlacementBottom:
+			below = append(below, layoutBand{&c.legendRect, legendHeight, margin})
+		case LegendPlacementLeft, LegendPlacementRight:
+			side += legendWidth + margin
+		}
+	}
+	// The bands next to the plot are separated from it by the plot margin
+	if len(above) > 0 {
+		above[len(above)-1].gap = margin
+	}
+	if len(below) > 0 {
+		below[0].gap = margin
+	}
+	stacked := 0.0
+	for _, band := range append(above, below...) {
+		stacked += band.height + band.gap
+	}
+
+	// Fit the plot in the remaining room. Small multiples fill all of it, and each
+	// panel is fitted in its cell when it is drawn.
+	roomWidth := page.W() - side
+	roomHeight := page.H() - stacked
+	var radius float64
+	var extent canvas.Rect
+	if c.Options.Facets.By != FacetNone {
+		extent = canvas.Rect{X0: -roomWidth / 2, Y0: -roomHeight / 2, X1: roomWidth / 2, Y1: roomHeight / 2}
+	} else {
+		maxRadius := c.Options.PlotOptions.Scale*math.Min(w, h)/2 - c.Options.PlotOptions.Padding
+		radius, extent = c.fitPlot(roomWidth, roomHeight, maxRadius)
+	}
+	middleHeight := extent.H()
+	if legendBeside {
+		middleHeight = math.Max(middleHeight, legendHeight)
+	}
+	if showTable && tablePlacement == TablePlacementRight {
+		middleHeight = math.Max(middleHeight, tableHeight)
+	}
+
+	// Stack the bands and the plot from the top, centered vertically on the page
+	y := page.Y1 - (page.H()-stacked-middleHeight)/2
+	for _, band := range above {
+		*band.rect = canvas.Rect{X0: page.X0, Y0: y - band.height, X1: page.X1, Y1: y}
+		y -= band.height + band.gap
+	}
+	middle := canvas.Rect{X0: page.X0, Y0: y - middleHeight, X1: page.X1, Y1: y}
+	y = middle.Y0
+	for _, band := range below {
+		y -= band.gap
+		*band.rect = canvas.Rect{X0: page.X0, Y0: y - band.height, X1: page.X1, Y1: y}
+		y -= band.height
+	}
+	if showLegend {
+		c.placeLegendInRow(middle, legendWidth, legendHeight)
+	}
+	if showTable && tablePlacement == TablePlacementBottom {
+		cx := page.Center().X
+		c.tableRect.X0, c.tableRect.X1 = cx-tableWidth/2, cx+tableWidth/2
+	}
+
+	// Place a left legend, the plot, a right table and a right legend next to each
+	// other, centered horizontally on the page
+	x := page.X0 + (page.W()-extent.W()-side)/2
+	legendY0, legendY1 := middle.Center().Y-legendHeight/2, middle.Center().Y+legendHeight/2
+	if legendBeside && placement == LegendPlacementLeft {
+		c.legendRect = canvas.Rect{X0: x, Y0: legendY0, X1: x + legendWidth, Y1: legendY1}
+		x += legendWidth + margin
+	}
+	box := canvas.Rect{
+		X0: x,
+		Y0: middle.Center().Y - extent.H()/2,
+		X1: x + extent.W(),
+		Y1: middle.Center().Y + extent.H()/2,
+	}
+	x = box.X1 + margin
+	if showTable && tablePlacement == TablePlacementRight {
+		c.tableRect = canvas.Rect{
+			X0: x,
+			Y0: middle.Center().Y - tableHeight/2,
+			X1: x + tableWidth,
+			Y1: middle.Center().Y + tableHeight/2,
+		}
+		x += tableWidth + margin
+	}
+	if legendBeside && placement == LegendPlacementRight {
+		c.legendRect = canvas.Rect{X0: x, Y0: legendY0, X1: x + legendWidth, Y1: legendY1}
+	}
+	if c.Options.Facets.By != FacetNone {
+		c.plotRect = box
+		return
+	}
+	center := box.Center().Sub(extent.Center())
+	half := radius + c.Options.PlotOptions.Padding
+	c.plotRect = canvas.Rect{
+		X0: center.X - half,
+		Y0: center.Y - half,
+		X1: center.X + half,
+		Y1: center.Y + half,
+	}
+}
+
+// placeLegendInRow sizes a top or bottom legend box to its entries, centered on the
+// page, and places a legend in a corner of the plot row
+func (c *Chart) placeLegendInRow(row canvas.Rect, width, height float64) {
+	switch c.Options.LegendOptions.Placement {
+	case LegendPlacementTop, LegendPlacementBottom:
+		cx := c.pageRect.Center().X
+		c.legendRect.X0, c.legendRect.X1 = cx-width/2, cx+width/2
+	case LegendPlacementTopLeft:
+		c.legendRect = canvas.Rect{X0: row.X0, Y0: row.Y1 - height, X1: row.X0 + width, Y1: row.Y1}
+	case LegendPlacementTopRight:
+		c.legendRect = canvas.Rect{X0: row.X1 - width, Y0: row.Y1 - height, X1: row.X1, Y1: row.Y1}
+	case LegendPlacementBottomLeft:
+		c.legendRect = canvas.Rect{X0: row.X0, Y0: row.Y0, X1: row.X0 + width, Y1: row.Y0 + height}
+	case LegendPlacementBottomRight:
+		c.legendRect = canvas.Rect{X0: row.X1 - width, Y0: row.Y0, X1: row.X1, Y1: row.Y0 + height}
+	}
+}
+
+// fitPlot returns the largest plot radius, up to the maximum radius, at which the
+// plot and its axis names fit in a box of the given size, and the bounds of the plot
+// at that radius relative to the plot center
+func (c *Chart) fitPlot(width, height, maxRadius float64) (float64, canvas.Rect) {
+	extent := c.plotExtent()
+	fits := func(r float64) bool {
+		e := extent(r)
+		return e.W() <= width && e.H() <= height
+	}
+	lo, hi := 0.0, math.Max(maxRadius, 0)
+	if fits(hi) {
+		return hi, extent(hi)
+	}
+	for range layoutIterations {
+		mid := (lo + hi) / 2
+		if fits(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, extent(lo)
+}
+
+// plotExtent returns a function that gives the bounds of the plot, relative to its
+// center, at a plot radius: the plot with its padding and the axis names placed at
+// that radius
+func (c *Chart) plotExtent() func(r float64) canvas.Rect {
+	names := c.axisNames()
+	padding := c.Options.PlotOptions.Padding
+	return func(r float64) canvas.Rect {
+		e := canvas.Rect{X0: -r - padding, Y0: -r - padding, X1: r + padding, Y1: r + padding}
+		placeAxisNames(names, r)
+		for _, name := range names {
+			for _, p := range name.corners(r) {
+				e = e.AddPoint(p)
+			}
+		}
+		return e
+	}
+}
+
+// legendSize returns the width and height of the legend including its padding,
+// within the minimum and maximum legend size
+func (c *Chart) legendSize() (float64, float64) {
+	legend := c.Options.LegendOptions
+	text := c.legendText(c.legendBreakWidth(), 0, 0)
+	width := text.Bounds().W() + 2*legend.Padding
+	height := textHeight(text) + 2*legend.Padding
+	width = math.Max(width, legend.MinWidth)
+	if legend.MaxWidth > 0 {
+		width = math.Min(width, legend.MaxWidth)
+	}
+	height = math.Max(height, legend.MinHeight)
+	if legend.MaxHeight > 0 {
+		height = math.Min(height, legend.MaxHeight)
+	}
+	return width, height
+}
+
+// legendBreakWidth returns the width at which flowing legend lines are broken, without
+// the padding: the maximum legend width, or the page width for top and bottom legends
+// and a share of it for the others if no maximum is set
+func (c *Chart) legendBreakWidth() float64 {
+	legend := c.Options.LegendOptions
+	width := c.pageRect.W()
+	if legend.Placement.stacked() {
+		width *= DefaultLegendMaxWidthRatio
+	}
+	if legend.MaxWidth > 0 {
+		width = math.Min(width, legend.MaxWidth)
+	}
+	return width - 2*legend.Padding
+}
+
+// textHeight returns the height of a text box from the top of its first line to the
+// bottom of its last line
+func textHeight(text *canvas.Text) float64 {
+	top, bottom := text.Heights()
+	return (top + bottom) * smidge
+}
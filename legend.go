@@ -1,5 +1,11 @@
 package spider
 
+import (
+	"fmt"
+
+	"github.com/tdewolff/canvas"
+)
+
 // LegendConfig represents configuration for the legend
 type LegendOptions struct {
 	Show             bool            `json:"show" yaml:"show"`                           // Whether to show the legend
@@ -15,6 +21,13 @@ type LegendOptions struct {
 	LegendStyle      Font            `json:"style" yaml:"style"`                         // Legend text style
 	Padding          float64         `json:"padding,omitempty" yaml:"padding,omitempty"` // Padding around legend items
 	ShowOutline      bool            `json:"show_outline" yaml:"show_outline"`           // Whether to show the outline
+	ShowHidden       bool            `json:"show_hidden" yaml:"show_hidden"`             // Whether hidden series are listed grayed out
+	Title            string          `json:"title" yaml:"title"`                         // Title above the legend entries
+	TitleStyle       Font            `json:"title_style" yaml:"title_style"`             // Legend title text style
+	Columns          int             `json:"columns" yaml:"columns"`                     // Number of entry columns (0 flows the entries, or calculates it from the rows)
+	Rows             int             `json:"rows" yaml:"rows"`                           // Number of entry rows (0 calculates it from the columns)
+	Order            LegendOrder     `json:"order" yaml:"order"`                         // Order of the entries (data, reverse or name)
+	Background       Color           `json:"background" yaml:"background"`               // Background color of the legend box (empty for none)
 }
 
 // DefaultLegendOptions returns default legend options
@@ -32,5 +45,110 @@ func DefaultLegendOptions() LegendOptions {
 		},
 		Padding:     2.0,
 		ShowOutline: false,
+		ShowHidden:  false,
+		TitleStyle: Font{
+			Size:  DefaultLegendTitleFontSize,
+			Color: Color("#000000"),
+		},
+		Columns: 0,
+		Rows:    0,
+		Order:   LegendOrderData,
+	}
+}
+
+// corner returns true if the legend is placed in a corner over the plot area, without
+// taking room from the plot
+func (p LegendPlacement) corner() bool {
+	switch p {
+	case LegendPlacementTopLeft, LegendPlacementTopRight, LegendPlacementBottomLeft, LegendPlacementBottomRight:
+		return true
+	}
+	return false
+}
+
+// stacked returns true if the legend entries flow one per line: for legends beside the
+// plot and in its corners
+func (p LegendPlacement) stacked() bool {
+	return p == LegendPlacementLeft || p == LegendPlacementRight || p.corner()
+}
+
+// alignment returns the horizontal and vertical alignment of the legend entries in
+// the legend box, towards the plot for legends beside it and towards the corner for
+// legends in a corner
+func (p LegendPlacement) alignment() (canvas.TextAlign, canvas.TextAlign) {
+	switch p {
+	case LegendPlacementTop:
+		return canvas.Center, canvas.Bottom
+	case LegendPlacementBottom:
+		return canvas.Center, canvas.Top
+	case LegendPlacementLeft:
+		return canvas.Right, canvas.Center
+	case LegendPlacementRight:
+		return canvas.Left, canvas.Center
+	case LegendPlacementTopLeft:
+		return canvas.Left, canvas.Top
+	case LegendPlacementTopRight:
+		return canvas.Right, canvas.Top
+	case LegendPlacementBottomLeft:
+		return canvas.Left, canvas.Bottom
+	case LegendPlacementBottomRight:
+		return canvas.Right, canvas.Bottom
+	default:
+		return canvas.Center, canvas.Center
+	}
+}
+
+// legendGrid returns the number of rows and columns of the legend entries. A missing
+// count is calculated so that n entries fit, and both are 0 if the entries flow.
+func (c *Chart) legendGrid(n int) (int, int) {
+	rows, cols := c.Options.LegendOptions.Rows, c.Options.LegendOptions.Columns
+	switch {
+	case rows > 0 && cols > 0:
+		rows = min(rows, (n+cols-1)/cols)
+	case cols > 0:
+		rows = (n + cols - 1) / cols
+	case rows > 0:
+		cols = (n + rows - 1) / rows
+		rows = (n + cols - 1) / cols
+	}
+	return rows, cols
+}
+
+// validateLegend checks the legend placement, entry order and grid
+func (c *Chart) validateLegend() error {
+	legend := c.Options.LegendOptions
+	switch legend.Placement {
+	case LegendPlacementTop, LegendPlacementBottom, LegendPlacementLeft, LegendPlacementRight,
+		LegendPlacementTopLeft, LegendPlacementTopRight, LegendPlacementBottomLeft, LegendPlacementBottomRight,
+		LegendPlacementNone:
+	default:
+		return &ValidationError{
+			Field:   "options.legend_options.placement",
+			Message: fmt.Sprintf("unknown legend placement: %s", legend.Placement),
+		}
+	}
+	switch legend.Order {
+	case LegendOrderData, LegendOrderReverse, LegendOrderName:
+	default:
+		return &ValidationError{
+			Field:   "options.legend_options.order",
+			Message: fmt.Sprintf("unknown legend order: %s", legend.Order),
+		}
+	}
+	if legend.Rows < 0 || legend.Columns < 0 {
+		return &ValidationError{
+			Field:   "options.legend_options.rows",
+			Message: "rows and columns must not be negative",
+		}
+	}
+	if legend.Rows == 0 || legend.Columns == 0 {
+		return nil
+	}
+	if n := len(c.legendItems()); legend.Rows*legend.Columns < n {
+		return &ValidationError{
+			Field:   "options.legend_options.rows",
+			Message: fmt.Sprintf("%d rows and %d columns do not fit %d entries", legend.Rows, legend.Columns, n),
+		}
 	}
+	return nil
 }
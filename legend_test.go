@@ -0,0 +1,73 @@
+package spider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+var legendSeries = seriesConfig(`
+    - {name: beta, data: {a: 1, b: 2, c: 3}}
+    - {name: hidden, visible: false, data: {a: 1, b: 1, c: 1}}
+    - {name: alpha, data: {a: 3, b: 2, c: 1}}
+    - {name: gamma, data: {a: 2, b: 2, c: 2}}
+`)
+
+func TestLegendItems(t *testing.T) {
+	tests := []struct {
+		options string
+		want    []string
+	}{
+		{"{}", []string{"beta", "alpha", "gamma"}},
+		{"{order: reverse}", []string{"gamma", "alpha", "beta"}},
+		{"{order: name}", []string{"alpha", "beta", "gamma"}},
+		{"{show_hidden: true}", []string{"beta", "hidden", "alpha", "gamma"}},
+		{"{show_hidden: true, order: reverse}", []string{"gamma", "alpha", "hidden", "beta"}},
+		{"{show_hidden: true, order: name}", []string{"alpha", "beta", "gamma", "hidden"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.options, func(t *testing.T) {
+			c, err := NewChartFromYAML([]byte("options: {legend_options: " + tt.options + "}" + legendSeries))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, i := range c.legendItems() {
+				names = append(names, c.Data.Series[i].Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("legend entries %v, want %v", names, tt.want)
+			}
+			drawChart(t, c)
+		})
+	}
+}
+
+func TestValidateLegend(t *testing.T) {
+	tests := []struct {
+		options string
+		wantErr string
+	}{
+		{"{rows: 1, columns: 3}", ""},
+		{"{rows: 2, columns: 2, show_hidden: true}", ""},
+		{"{placement: center}", "unknown legend placement"},
+		{"{order: size}", "unknown legend order"},
+		{"{columns: -1}", "must not be negative"},
+		{"{rows: 1, columns: 2}", "1 rows and 2 columns do not fit 3 entries"},
+		{"{rows: 1, columns: 3, show_hidden: true}", "1 rows and 3 columns do not fit 4 entries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.options, func(t *testing.T) {
+			_, err := NewChartFromYAML([]byte("options: {legend_options: " + tt.options + "}" + legendSeries))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package spider
+
+import (
+	"math"
+
+	"github.com/tdewolff/canvas"
+)
+
+// markerPath returns the outline of a point marker of the given shape and size,
+// centered on the origin. Custom markers are scaled from the SVG path data so that
+// they fit in a square of the given size. The result is nil if the shape draws
+// nothing.
+func markerPath(shape PointShape, size float64, svgPath string) *canvas.Path {
+	r := size / 2
+	switch shape {
+	case PointShapeCircle:
+		return canvas.Circle(r)
+	case PointShapeSquare:
+		return canvas.Rectangle(size, size).Translate(-r, -r)
+	case PointShapeTriangle:
+		return canvas.RegularPolygon(3, r, true)
+	case PointShapeTriangleDown:
+		return canvas.RegularPolygon(3, r, false)
+	case PointShapeDiamond:
+		return canvas.RegularPolygon(4, r, true)
+	case PointShapePentagon:
+		return canvas.RegularPolygon(5, r, true)
+	case PointShapeHexagon:
+		return canvas.RegularPolygon(6, r, true)
+	case PointShapeStar:
+		return canvas.StarPolygon(5, r, r*0.45, true)
+	case PointShapePlus:
+		return plusPath(r)
+	case PointShapeCross:
+		return plusPath(r).Rotate(45)
+	case PointShapeCustom:
+		p, err := parseMarkerPath(svgPath)
+		if err != nil {
+			return nil
+		}
+		bounds := p.Bounds()
+		extent := math.Max(bounds.W(), bounds.H())
+		if extent == 0 {
+			return nil
+		}
+		// SVG coordinates point down, so flip the path around its center
+		center := bounds.Center()
+		m := canvas.Identity.Scale(size/extent, -size/extent).Translate(-center.X, -center.Y)
+		return p.Transform(m)
+	default:
+		return nil
+	}
+}
+
+// parseMarkerPath parses the SVG path data of a custom point marker
+func parseMarkerPath(svgPath string) (*canvas.Path, error) {
+	return canvas.ParseSVGPath(svgPath)
+}
+
+// plusPath returns the outline of a plus sign with arms of length r and a third of the
+// marker size wide
+func plusPath(r float64) *canvas.Path {
+	w := r / 3
+	p := &canvas.Path{}
+	p.MoveTo(w, w)
+	for _, q := range []canvas.Point{
+		{X: w, Y: r}, {X: -w, Y: r}, {X: -w, Y: w}, {X: -r, Y: w}, {X: -r, Y: -w}, {X: -w, Y: -w},
+		{X: -w, Y: -r}, {X: w, Y: -r}, {X: w, Y: -w}, {X: r, Y: -w}, {X: r, Y: w},
+	} {
+		p.LineTo(q.X, q.Y)
+	}
+	p.Close()
+	return p
+}
@@ -0,0 +1,177 @@
+package spider
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+)
+
+// ReferenceOptions represents options for comparing the series against a reference
+// (baseline) series
+type ReferenceOptions struct {
+	Series         string         `json:"series,omitempty" yaml:"series,omitempty"` // Name of the reference series (empty means no reference)
+	ShowDeltas     bool           `json:"show_deltas" yaml:"show_deltas"`           // Whether to shade where the other series improve on or regress from the reference
+	ImprovedColor  Color          `json:"improved_color" yaml:"improved_color"`     // Color of the shading where a series improves on the reference
+	RegressedColor Color          `json:"regressed_color" yaml:"regressed_color"`   // Color of the shading where a series regresses from the reference
+	DeltaOpacity   float64        `json:"delta_opacity" yaml:"delta_opacity"`       // Opacity of the delta shading
+	DeltaLabels    DeltaLabelMode `json:"delta_labels" yaml:"delta_labels"`         // Where the percent deltas are shown (none, legend or points)
+}
+
+// DefaultReferenceOptions returns default reference options
+func DefaultReferenceOptions() ReferenceOptions {
+	return ReferenceOptions{
+		Series:         "",
+		ShowDeltas:     false,
+		ImprovedColor:  DefaultImprovedColor,
+		RegressedColor: DefaultRegressedColor,
+		DeltaOpacity:   DefaultDeltaOpacity,
+		DeltaLabels:    DeltaLabelNone,
+	}
+}
+
+// referenceIndex returns the index of the reference series, or -1 if there is none
+func (c *Chart) referenceIndex() int {
+	if c.Options.Reference.Series == "" {
+		return -1
+	}
+	for i := range c.Data.Series {
+		if c.Data.Series[i].Name == c.Options.Reference.Series {
+			return i
+		}
+	}
+	return -1
+}
+
+// isReference returns true if the series with the given index is the reference series
+func (c *Chart) isReference(i int) bool {
+	return i == c.referenceIndex()
+}
+
+// delta returns the change of the series with index i from the reference series on
+// the axis with index j, as a fraction of the reference value, and whether it is an
+// improvement. Lower values are better on inverted axes. The last result is false if
+// either series has no value on the axis.
+func (c *Chart) delta(i int, j int) (float64, bool, bool) {
+	ref := c.referenceIndex()
+	if ref < 0 || i == ref {
+		return 0, false, false
+	}
+	axis := &c.Data.Axes[j]
+	value, ok := c.Data.Series[i].value(axis.Name)
+	refValue, refOk := c.Data.Series[ref].value(axis.Name)
+	if !ok || !refOk {
+		return 0, false, false
+	}
+	improved := value > refValue
+	if axis.Invert {
+		improved = value < refValue
+	}
+	return (value - refValue) / math.Abs(refValue), improved, true
+}
+
+// meanImprovement returns the mean relative improvement of the series with index i
+// over the reference series across all axes, counting regressions as negative
+func (c *Chart) meanImprovement(i int) (float64, bool) {
+	sum, n := 0.0, 0
+	for j := range c.Data.Axes {
+		d, _, ok := c.delta(i, j)
+		if !ok || math.IsInf(d, 0) || math.IsNaN(d) {
+			continue
+		}
+		if c.Data.Axes[j].Invert {
+			d = -d
+		}
+		sum += d
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+// formatDelta formats a relative change as a signed percentage
+func formatDelta(d float64) string {
+	if math.Abs(d) >= 0.1 {
+		return fmt.Sprintf("%+.0f%%", d*100)
+	}
+	return fmt.Sprintf("%+.1f%%", d*100)
+}
+
+// legendLabel returns the legend text of the series with the given index, including
+// the range of its history snapshots and its mean improvement over the reference when
+// deltas are shown in the legend
+func (c *Chart) legendLabel(i int) string {
+	var notes []string
+	if r, ok := c.Data.Series[i].historyRange(); ok {
+		notes = append(notes, r)
+	}
+	if c.Options.Reference.DeltaLabels == DeltaLabelLegend && !c.isReference(i) {
+		if d, ok := c.meanImprovement(i); ok {
+			notes = append(notes, formatDelta(d))
+		}
+	}
+	if len(notes) == 0 {
+		return c.Data.Series[i].Name
+	}
+	return fmt.Sprintf("%s (%s)", c.Data.Series[i].Name, strings.Join(notes, ", "))
+}
+
+// drawDeltas shades, per axis, the region between every series and the reference
+// series in the improved or regressed color. Each region spans from the middle of the
+// edge to the previous axis to the middle of the edge to the next axis.
+func (c *Chart) drawDeltas(ctx *canvas.Context, scales []axisScale) {
+	ref := c.referenceIndex()
+	opts := c.Options.Reference
+	if ref < 0 || !opts.ShowDeltas {
+		return
+	}
+	n := len(c.Data.Axes)
+	refPoints, refPresent := c.seriesPoints(ref, scales)
+	ctx.SetStrokeColor(canvas.Transparent)
+	for i := range c.Data.Series {
+		if i == ref || !c.Data.Series[i].IsVisible() {
+			continue
+		}
+		points, present := c.seriesPoints(i, scales)
+		// halfway returns the middle of the edge from axis j to axis k of an outline,
+		// or the point on axis j itself if the outline has no value on axis k
+		halfway := func(pts []canvas.Point, ok []bool, j int, k int) canvas.Point {
+			if !ok[k] {
+				return pts[j]
+			}
+			return pts[j].Interpolate(pts[k], 0.5)
+		}
+		for j := range c.Data.Axes {
+			d, improved, ok := c.delta(i, j)
+			if !ok || d == 0 {
+				continue
+			}
+			prev, next := (j+n-1)%n, (j+1)%n
+			region := &canvas.Path{}
+			for k, p := range []canvas.Point{
+				halfway(refPoints, refPresent, j, prev),
+				refPoints[j],
+				halfway(refPoints, refPresent, j, next),
+				halfway(points, present, j, next),
+				points[j],
+				halfway(points, present, j, prev),
+			} {
+				if k == 0 {
+					region.MoveTo(p.X, p.Y)
+				} else {
+					region.LineTo(p.X, p.Y)
+				}
+			}
+			region.Close()
+			col := opts.RegressedColor
+			if improved {
+				col = opts.ImprovedColor
+			}
+			ctx.SetFillColor(col.ToCanvasColorWithOpacity(opts.DeltaOpacity))
+			ctx.DrawPath(0, 0, region)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package spider
+
+import "math"
+
+// tickEpsilon is the tolerance used when comparing tick positions to the axis ends
+const tickEpsilon = 1e-9
+
+// axisScale maps the data values of a single axis onto the unit interval [0, 1],
+// where 0 is the center of the plot and 1 is the outer ring
+type axisScale struct {
+	Type   ScaleType // Scale type
+	Min    float64   // Smallest value on the axis
+	Max    float64   // Largest value on the axis
+	Invert bool      // Whether Max is at the center and Min at the outer ring
+	Step   float64   // Major tick step for nice linear ticks, zero for fixed ticks
+}
+
+// base returns the logarithm base of the scale, or 0 for linear scales
+func (s axisScale) base() float64 {
+	switch s.Type {
+	case ScaleTypeLog10:
+		return 10
+	case ScaleTypeLog2:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// isLog returns true if the scale is logarithmic
+func (s axisScale) isLog() bool {
+	return s.base() > 0
+}
+
+// fraction returns the position of value along the axis as a fraction of the radius
+func (s axisScale) fraction(value float64) float64 {
+	if s.Invert {
+		return 1 - s.rawFraction(value)
+	}
+	return s.rawFraction(value)
+}
+
+// rawFraction returns the position of value between Min and Max, ignoring Invert
+func (s axisScale) rawFraction(value float64) float64 {
+	if s.isLog() {
+		if value <= 0 {
+			return math.Inf(-1)
+		}
+		b := s.base()
+		return linmap(logb(s.Min, b), logb(s.Max, b), 0, 1, logb(value, b))
+	}
+	return linmap(s.Min, s.Max, 0, 1, value)
+}
+
+// radius returns the distance from the plot center of value on a plot with the given
+// outer radius. Values beyond the center end of the axis are drawn at the center.
+func (s axisScale) radius(value float64, outer float64) float64 {
+	return math.Max(0, s.fraction(value)) * outer
+}
+
+// majorTicks returns the values of the major ticks, including both ends of the axis.
+// Linear scales are divided into n+1 equal intervals, or into multiples of Step when
+// it is set. Logarithmic scales get one tick per power of the base.
+func (s axisScale) majorTicks(n int) []float64 {
+	if s.Step > 0 && !s.isLog() {
+		return stepTicks(s.Min, s.Max, s.Step)
+	}
+	if s.isLog() {
+		b := s.base()
+		var ticks []float64
+		kMin := math.Ceil(logb(s.Min, b) - tickEpsilon)
+		kMax := math.Floor(logb(s.Max, b) + tickEpsilon)
+		if kMin > logb(s.Min, b)+tickEpsilon {
+			ticks = append(ticks, s.Min)
+		}
+		for k := kMin; k <= kMax; k++ {
+			ticks = append(ticks, math.Pow(b, k))
+		}
+		if kMax < logb(s.Max, b)-tickEpsilon {
+			ticks = append(ticks, s.Max)
+		}
+		return ticks
+	}
+	return linspace(s.Min, s.Max, n+2)
+}
+
+// minorTicks returns the values of the minor ticks between the given major ticks.
+// Linear scales get n evenly spaced ticks per interval. Base-10 scales get ticks at
+// 2..9 times each power of ten, and base-2 scales get n ticks per octave, spaced
+// evenly in value so that they appear compressed towards the next power.
+func (s axisScale) minorTicks(major []float64, n int) []float64 {
+	var ticks []float64
+	if s.Step > 0 && !s.isLog() {
+		// Skip the minor ticks that coincide with a major tick
+		minorStep := s.Step / float64(n+1)
+		for i, v := range stepTicks(s.Min, s.Max, minorStep) {
+			if i > 0 && math.Abs(math.Remainder(v, s.Step)) > tickEpsilon*s.Step {
+				ticks = append(ticks, v)
+			}
+		}
+		return ticks
+	}
+	if s.Type == ScaleTypeLog10 {
+		kMin := math.Floor(math.Log10(s.Min) + tickEpsilon)
+		kMax := math.Ceil(math.Log10(s.Max) - tickEpsilon)
+		for k := kMin; k < kMax; k++ {
+			p := math.Pow(10, k)
+			for m := 2.0; m < 10; m++ {
+				if v := m * p; v > s.Min && v < s.Max {
+					ticks = append(ticks, v)
+				}
+			}
+		}
+		return ticks
+	}
+	for i := 0; i < len(major)-1; i++ {
+		minor := linspace(major[i], major[i+1], n+2)
+		ticks = append(ticks, minor[1:len(minor)-1]...)
+	}
+	return ticks
+}
+
+// stepTicks returns min, every multiple of step strictly between min and max, and max
+func stepTicks(min float64, max float64, step float64) []float64 {
+	ticks := []float64{min}
+	for k := math.Floor(min/step+tickEpsilon) + 1; k*step < max-tickEpsilon*step; k++ {
+		ticks = append(ticks, k*step)
+	}
+	return append(ticks, max)
+}
+
+// niceStep returns a round step size (1, 2 or 5 times a power of ten) that divides
+// span into at most the given number of intervals
+func niceStep(span float64, intervals int) float64 {
+	if span <= 0 || intervals < 1 {
+		return 0
+	}
+	raw := span / float64(intervals)
+	p := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch f := raw / p; {
+	case f <= 1:
+		return p
+	case f <= 2:
+		return 2 * p
+	case f <= 5:
+		return 5 * p
+	default:
+		return 10 * p
+	}
+}
+
+// isInterior returns true if the fraction lies strictly inside the axis
+func isInterior(f float64) bool {
+	return f > tickEpsilon && f < 1-tickEpsilon
+}
+
+// logb returns the base-b logarithm of x
+func logb(x float64, b float64) float64 {
+	switch b {
+	case 10:
+		return math.Log10(x)
+	case 2:
+		return math.Log2(x)
+	default:
+		return math.Log(x) / math.Log(b)
+	}
+}
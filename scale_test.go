@@ -0,0 +1,94 @@
+package spider
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRawFraction(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale axisScale
+		value float64
+		want  float64
+	}{
+		{"linear center", axisScale{Type: ScaleTypeLinear, Min: 0, Max: 10}, 0, 0},
+		{"linear middle", axisScale{Type: ScaleTypeLinear, Min: 0, Max: 10}, 5, 0.5},
+		{"linear with minimum", axisScale{Type: ScaleTypeLinear, Min: 90, Max: 100}, 95, 0.5},
+		{"linear beyond max", axisScale{Type: ScaleTypeLinear, Min: 0, Max: 10}, 15, 1.5},
+		{"log10 decade", axisScale{Type: ScaleTypeLog10, Min: 1, Max: 1000}, 10, 1.0 / 3},
+		{"log10 max", axisScale{Type: ScaleTypeLog10, Min: 1, Max: 1000}, 1000, 1},
+		{"log2 octave", axisScale{Type: ScaleTypeLog2, Min: 1, Max: 16}, 4, 0.5},
+		{"log zero", axisScale{Type: ScaleTypeLog10, Min: 1, Max: 1000}, 0, math.Inf(-1)},
+		{"log negative", axisScale{Type: ScaleTypeLog2, Min: 1, Max: 16}, -4, math.Inf(-1)},
+		{"inverted is ignored", axisScale{Type: ScaleTypeLinear, Min: 0, Max: 10, Invert: true}, 2, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.scale.rawFraction(tt.value)
+			if math.IsInf(tt.want, 0) {
+				if got != tt.want {
+					t.Errorf("rawFraction(%g) = %g, want %g", tt.value, got, tt.want)
+				}
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("rawFraction(%g) = %g, want %g", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNiceStep(t *testing.T) {
+	tests := []struct {
+		span      float64
+		intervals int
+		want      float64
+	}{
+		{10, 10, 1},
+		{10, 5, 2},
+		{10, 4, 5},
+		{45, 5, 10},
+		{100, 5, 20},
+		{1, 5, 0.2},
+		{0.03, 3, 0.01},
+		{7, 1, 10},
+		{0, 5, 0},
+		{-10, 5, 0},
+		{10, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := niceStep(tt.span, tt.intervals); math.Abs(got-tt.want) > 1e-9*math.Max(1, tt.want) {
+			t.Errorf("niceStep(%g, %d) = %g, want %g", tt.span, tt.intervals, got, tt.want)
+		}
+	}
+}
+
+func TestMajorTicks(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale axisScale
+		n     int
+		want  []float64
+	}{
+		{"fixed", axisScale{Type: ScaleTypeLinear, Min: 0, Max: 10}, 3, []float64{0, 2.5, 5, 7.5, 10}},
+		{"step", axisScale{Type: ScaleTypeLinear, Min: 0, Max: 10, Step: 2}, 3, []float64{0, 2, 4, 6, 8, 10}},
+		{"step with uneven ends", axisScale{Type: ScaleTypeLinear, Min: 1, Max: 9, Step: 2}, 3, []float64{1, 2, 4, 6, 8, 9}},
+		{"log10 decades", axisScale{Type: ScaleTypeLog10, Min: 1, Max: 1000}, 3, []float64{1, 10, 100, 1000}},
+		{"log10 partial decades", axisScale{Type: ScaleTypeLog10, Min: 2, Max: 500}, 3, []float64{2, 10, 100, 500}},
+		{"log2 ignores step", axisScale{Type: ScaleTypeLog2, Min: 1, Max: 16, Step: 5}, 3, []float64{1, 2, 4, 8, 16}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.scale.majorTicks(tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("majorTicks(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9*math.Max(1, tt.want[i]) {
+					t.Fatalf("majorTicks(%d) = %v, want %v", tt.n, got, tt.want)
+				}
+			}
+		})
+	}
+}
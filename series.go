@@ -1,41 +1,175 @@
 package spider
 
-import "slices"
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
 
 type SeriesOptions struct {
-	LineThickness      float64    `json:"line_thickness" yaml:"line_thickness"`             // Thickness of the line in millimeters
-	LineColor          Color      `json:"line_color" yaml:"line_color"`                     // Color of the line
-	FillOpacity        float64    `json:"fill_opacity" yaml:"fill_opacity"`                 // Opacity of the fill
-	FillColor          Color      `json:"fill_color" yaml:"fill_color"`                     // Color of the fill
-	PointSize          float64    `json:"point_size" yaml:"point_size"`                     // Size of the point in millimeters
-	PointLineThickness float64    `json:"point_line_thickness" yaml:"point_line_thickness"` // Thickness of the point line in millimeters
-	PointStrokeColor   Color      `json:"point_color" yaml:"point_color"`                   // Color of the point
-	PointFillColor     Color      `json:"point_fill_color" yaml:"point_fill_color"`         // Color of the point fill
-	PointFillOpacity   float64    `json:"point_fill_opacity" yaml:"point_fill_opacity"`     // Opacity of the point fill
-	PointShape         PointShape `json:"point_shape" yaml:"point_shape"`                   // Shape of the point
+	LineThickness         float64        `json:"line_thickness" yaml:"line_thickness"`                   // Thickness of the line in millimeters
+	LineColor             Color          `json:"line_color" yaml:"line_color"`                           // Color of the line
+	Curve                 CurveType      `json:"curve" yaml:"curve"`                                     // How the points are connected (linear or smooth)
+	Tension               float64        `json:"tension" yaml:"tension"`                                 // Tension of smooth curves, from 0 (Catmull-Rom) to 1 (straight lines)
+	LineStyle             LineStyle      `json:"line_style" yaml:"line_style"`                           // Dash pattern of the line (solid, dashed, dotted or dash_dot)
+	Dashes                []float64      `json:"dashes,omitempty" yaml:"dashes,omitempty"`               // Custom dash pattern in millimeters (overrides line_style)
+	LineCap               LineCap        `json:"line_cap" yaml:"line_cap"`                               // Shape of the line and dash ends (butt, round or square)
+	LineJoin              LineJoin       `json:"line_join" yaml:"line_join"`                             // Shape of the line corners (miter, round or bevel)
+	OutlineOnly           bool           `json:"outline_only" yaml:"outline_only"`                       // Whether to draw only the outline without fill and with hollow points
+	FillOpacity           float64        `json:"fill_opacity" yaml:"fill_opacity"`                       // Opacity of the fill
+	FillColor             Color          `json:"fill_color" yaml:"fill_color"`                           // Color of the fill
+	FillStyle             FillStyle      `json:"fill_style" yaml:"fill_style"`                           // How the area is filled (solid, hatch or radial_gradient)
+	HatchPattern          HatchPattern   `json:"hatch_pattern" yaml:"hatch_pattern"`                     // Pattern of hatch fills (diagonal, cross or dots)
+	HatchSpacing          float64        `json:"hatch_spacing" yaml:"hatch_spacing"`                     // Distance between hatch lines or dots in millimeters
+	HatchAngle            float64        `json:"hatch_angle" yaml:"hatch_angle"`                         // Counter-clockwise rotation of the hatch pattern in degrees
+	HatchThickness        float64        `json:"hatch_thickness" yaml:"hatch_thickness"`                 // Thickness of hatch lines, or radius of hatch dots, in millimeters
+	GradientCenterColor   Color          `json:"gradient_center_color" yaml:"gradient_center_color"`     // Color of radial gradient fills at the center (defaults to the fill color)
+	GradientCenterOpacity float64        `json:"gradient_center_opacity" yaml:"gradient_center_opacity"` // Opacity of radial gradient fills at the center
+	PointSize             float64        `json:"point_size" yaml:"point_size"`                           // Size of the point in millimeters
+	PointLineThickness    float64        `json:"point_line_thickness" yaml:"point_line_thickness"`       // Thickness of the point line in millimeters
+	PointStrokeColor      Color          `json:"point_color" yaml:"point_color"`                         // Color of the point
+	PointFillColor        Color          `json:"point_fill_color" yaml:"point_fill_color"`               // Color of the point fill
+	PointFillOpacity      float64        `json:"point_fill_opacity" yaml:"point_fill_opacity"`           // Opacity of the point fill
+	PointShape            PointShape     `json:"point_shape" yaml:"point_shape"`                         // Shape of the point
+	PointPath             string         `json:"point_path,omitempty" yaml:"point_path,omitempty"`       // SVG path data of custom point shapes
+	RangeStyle            RangeStyle     `json:"range_style" yaml:"range_style"`                         // How uncertainty ranges are drawn (band, error_bars or none)
+	RangeColor            Color          `json:"range_color" yaml:"range_color"`                         // Color of the uncertainty ranges (defaults to the line color)
+	RangeOpacity          float64        `json:"range_opacity" yaml:"range_opacity"`                     // Opacity of the uncertainty band
+	ErrorBarCapWidth      float64        `json:"error_bar_cap_width" yaml:"error_bar_cap_width"`         // Width of the error bar caps in millimeters
+	ZOrder                int            `json:"z_order" yaml:"z_order"`                                 // Drawing order of the series, higher values are drawn on top
+	ValueLabels           ValueLabelMode `json:"value_labels,omitempty" yaml:"value_labels,omitempty"`   // Which values of the series are labeled (overrides the chart value label mode)
 }
 
 // DefaultSeriesOptions returns a default series options
 func DefaultSeriesOptions() SeriesOptions {
 	return SeriesOptions{
-		LineThickness:      DefaultSeriesLineThickness,
-		LineColor:          "",
-		FillOpacity:        0.0,
-		FillColor:          "",
-		PointSize:          DefaultPointSize,
-		PointLineThickness: 0.0,
-		PointStrokeColor:   "",
-		PointFillColor:     "",
-		PointFillOpacity:   1.0,
-		PointShape:         "",
+		LineThickness:         DefaultSeriesLineThickness,
+		LineColor:             "",
+		Curve:                 CurveLinear,
+		Tension:               0.0,
+		LineStyle:             LineStyleSolid,
+		LineCap:               LineCapButt,
+		LineJoin:              LineJoinMiter,
+		OutlineOnly:           false,
+		FillOpacity:           0.0,
+		FillColor:             "",
+		FillStyle:             FillStyleSolid,
+		HatchPattern:          HatchPatternDiagonal,
+		HatchSpacing:          DefaultHatchSpacing,
+		HatchAngle:            0.0,
+		HatchThickness:        DefaultHatchThickness,
+		GradientCenterColor:   "",
+		GradientCenterOpacity: 0.0,
+		PointSize:             DefaultPointSize,
+		PointLineThickness:    0.0,
+		PointStrokeColor:      "",
+		PointFillColor:        "",
+		PointFillOpacity:      1.0,
+		PointShape:            "",
+		RangeStyle:            RangeStyleBand,
+		RangeColor:            "",
+		RangeOpacity:          DefaultRangeOpacity,
+		ErrorBarCapWidth:      DefaultErrorBarCapWidth,
 	}
 }
 
+// dashPattern returns the dash pattern in millimeters for a line of the given width,
+// or nil for solid lines
+func (o SeriesOptions) dashPattern(width float64) []float64 {
+	if len(o.Dashes) > 0 {
+		return o.Dashes
+	}
+	return o.LineStyle.dashPattern(width)
+}
+
+// ValueRange represents the uncertainty of a single series value, given either as
+// an absolute low/high range or as a symmetric ± error. Fields are pointers so that
+// an explicit zero can be told apart from an unset field.
+type ValueRange struct {
+	Low   *float64 `json:"low,omitempty" yaml:"low,omitempty"`     // Lower bound of the range (requires high)
+	High  *float64 `json:"high,omitempty" yaml:"high,omitempty"`   // Upper bound of the range (requires low)
+	Error *float64 `json:"error,omitempty" yaml:"error,omitempty"` // Symmetric error around the value (instead of low and high)
+}
+
+// Bounds returns the lower and upper bound of the range around value. A missing bound
+// is the value itself.
+func (r ValueRange) Bounds(value float64) (float64, float64) {
+	if r.Error != nil {
+		return value - *r.Error, value + *r.Error
+	}
+	low, high := value, value
+	if r.Low != nil {
+		low = *r.Low
+	}
+	if r.High != nil {
+		high = *r.High
+	}
+	return low, high
+}
+
 // Series represents a data series in the spider chart
 type Series struct {
-	Name    string             `json:"name" yaml:"name"`       // Series name
-	Data    map[string]float64 `json:"data" yaml:"data"`       // Data values keyed by axis name
-	Options SeriesOptions      `json:"options" yaml:"options"` // Series options
+	Name             string                `json:"name" yaml:"name"`                                                 // Series name
+	Data             map[string]float64    `json:"data" yaml:"data"`                                                 // Data values keyed by axis name
+	Ranges           map[string]ValueRange `json:"ranges,omitempty" yaml:"ranges,omitempty"`                         // Optional uncertainty ranges keyed by axis name
+	Samples          map[string][]float64  `json:"samples,omitempty" yaml:"samples,omitempty"`                       // Optional raw samples keyed by axis name, aggregated into data and ranges
+	History          []Snapshot            `json:"history,omitempty" yaml:"history,omitempty"`                       // Optional snapshots ordered from oldest to latest, the latest being plotted as the series
+	Aggregation      AggregationOptions    `json:"aggregation,omitempty" yaml:"aggregation,omitempty"`               // How the samples are aggregated
+	Options          SeriesOptions         `json:"options" yaml:"options"`                                           // Series options
+	Group            string                `json:"group,omitempty" yaml:"group,omitempty"`                           // Name of the panel the series shares with its group when faceting by group
+	Visible          *bool                 `json:"visible,omitempty" yaml:"visible,omitempty"`                       // Whether the series is drawn (defaults to true)
+	ExcludeFromScale bool                  `json:"exclude_from_scale,omitempty" yaml:"exclude_from_scale,omitempty"` // Whether the series is left out of auto-calculated axis ranges
+	values           map[string]float64    `json:"-" yaml:"-"`                                                       // Plotted values, the data with the latest snapshot and the aggregated samples
+	ranges           map[string]ValueRange `json:"-" yaml:"-"`                                                       // Plotted uncertainty ranges, the ranges with those of the aggregated samples
+}
+
+// prepare derives the plotted values and ranges of the series from its data, its
+// latest history snapshot and its samples. They are rebuilt every time the chart is
+// drawn, leaving the data and ranges as they were given. An axis with samples must not
+// also have a data value or a range, and an axis in the latest snapshot must not also
+// have a data value or samples.
+func (s *Series) prepare() error {
+	for key := range s.Samples {
+		if _, ok := s.Data[key]; ok {
+			return &ValidationError{
+				Field:   "series.samples",
+				Message: fmt.Sprintf("series %s has both data and samples for axis %s", s.Name, key),
+			}
+		}
+		if _, ok := s.Ranges[key]; ok {
+			return &ValidationError{
+				Field:   "series.samples",
+				Message: fmt.Sprintf("series %s has both a range and samples for axis %s", s.Name, key),
+			}
+		}
+	}
+	if len(s.History) > 0 {
+		for key := range s.History[len(s.History)-1].Data {
+			_, hasData := s.Data[key]
+			_, hasSamples := s.Samples[key]
+			if hasData || hasSamples {
+				return &ValidationError{
+					Field:   "series.history",
+					Message: fmt.Sprintf("series %s has both history and data or samples for axis %s", s.Name, key),
+				}
+			}
+		}
+	}
+	s.values = maps.Clone(s.Data)
+	if s.values == nil {
+		s.values = make(map[string]float64)
+	}
+	s.ranges = maps.Clone(s.Ranges)
+	if s.ranges == nil {
+		s.ranges = make(map[string]ValueRange)
+	}
+	s.applyHistory()
+	return s.aggregateSamples()
+}
+
+// IsVisible returns true unless the series is hidden with visible: false
+func (s *Series) IsVisible() bool {
+	return s.Visible == nil || *s.Visible
 }
 
 // GetDataValue returns the data value for a given axis name, or 0 if not found
@@ -46,11 +180,32 @@ func (s *Series) GetDataValue(axisName string) float64 {
 	return 0.0
 }
 
+// GetValue returns the data value for a given axis name and whether it is present
+func (s *Series) GetValue(axisName string) (float64, bool) {
+	val, ok := s.Data[axisName]
+	return val, ok
+}
+
 // ValidateData checks if the series data matches the provided axis names
 func (s *Series) ValidateData(axisNames []string) error {
+	if err := s.prepare(); err != nil {
+		return err
+	}
+	return s.validateData(axisNames, false)
+}
+
+// value returns the plotted value for a given axis name and whether it is present
+func (s *Series) value(axisName string) (float64, bool) {
+	val, ok := s.values[axisName]
+	return val, ok
+}
+
+// validateData checks that the plotted values only refer to the provided axis names,
+// and that there is a value for every axis unless allowMissing is set
+func (s *Series) validateData(axisNames []string, allowMissing bool) error {
 	// Check that all axes have corresponding data
 	for _, axisName := range axisNames {
-		if _, ok := s.Data[axisName]; !ok {
+		if _, ok := s.values[axisName]; !ok && !allowMissing {
 			return &ValidationError{
 				Field:   "series.data",
 				Message: "missing data for axis: " + axisName,
@@ -58,7 +213,7 @@ func (s *Series) ValidateData(axisNames []string) error {
 		}
 	}
 	// Check that there are no extra data keys
-	for key := range s.Data {
+	for key := range s.values {
 		if !slices.Contains(axisNames, key) {
 			return &ValidationError{
 				Field:   "series.data",
@@ -66,14 +221,77 @@ func (s *Series) ValidateData(axisNames []string) error {
 			}
 		}
 	}
+	// Check that ranges refer to axes and contain their values
+	for key, r := range s.ranges {
+		if !slices.Contains(axisNames, key) {
+			return &ValidationError{
+				Field:   "series.ranges",
+				Message: "range key not matching any axis: " + key,
+			}
+		}
+		if _, ok := s.values[key]; !ok {
+			return &ValidationError{
+				Field:   "series.ranges",
+				Message: "range for axis without a value: " + key,
+			}
+		}
+		if r.Error != nil && (r.Low != nil || r.High != nil) {
+			return &ValidationError{
+				Field:   "series.ranges",
+				Message: "range with both an error and low/high bounds for axis: " + key,
+			}
+		}
+		if r.Error == nil && (r.Low == nil || r.High == nil) {
+			return &ValidationError{
+				Field:   "series.ranges",
+				Message: "range without an error or a pair of low and high bounds for axis: " + key,
+			}
+		}
+		if r.Error != nil && *r.Error < 0 {
+			return &ValidationError{
+				Field:   "series.ranges",
+				Message: fmt.Sprintf("negative error for axis %s: %g", key, *r.Error),
+			}
+		}
+		// Ranges derived from samples may lie beside a statistic such as a percentile
+		if _, sampled := s.Samples[key]; sampled {
+			continue
+		}
+		value := s.values[key]
+		if low, high := r.Bounds(value); low > value || value > high {
+			return &ValidationError{
+				Field:   "series.ranges",
+				Message: fmt.Sprintf("range %g to %g does not contain the value %g for axis %s", low, high, value, key),
+			}
+		}
+	}
 	return nil
 }
 
-// getAllSeriesData extracts all data maps from series for max calculation
+// getAllSeriesData extracts all data maps from series for max calculation. The
+// bounds of uncertainty ranges are included so that they fit inside the axes. Hidden
+// series are included unless they are excluded from the scale.
 func getAllSeriesData(series []Series) []map[string]float64 {
-	result := make([]map[string]float64, len(series))
-	for i, s := range series {
-		result[i] = s.Data
+	result := make([]map[string]float64, 0, len(series))
+	for _, s := range series {
+		if s.ExcludeFromScale {
+			continue
+		}
+		result = append(result, s.values)
+		for _, snapshot := range s.History {
+			result = append(result, snapshot.Data)
+		}
+		if len(s.ranges) == 0 {
+			continue
+		}
+		lows := make(map[string]float64, len(s.ranges))
+		highs := make(map[string]float64, len(s.ranges))
+		for key, r := range s.ranges {
+			if val, ok := s.values[key]; ok {
+				lows[key], highs[key] = r.Bounds(val)
+			}
+		}
+		result = append(result, lows, highs)
 	}
 	return result
 }
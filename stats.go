@@ -0,0 +1,193 @@
+package spider
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AggregationOptions represents how the raw samples of a series are reduced to the
+// plotted values and their uncertainty ranges
+type AggregationOptions struct {
+	Statistic       Statistic       `json:"statistic" yaml:"statistic"`               // Plotted statistic (mean, median, min, max or a percentile such as p90)
+	Uncertainty     UncertaintyType `json:"uncertainty" yaml:"uncertainty"`           // Uncertainty range derived from the samples (none, stddev, iqr or bootstrap_ci)
+	ConfidenceLevel float64         `json:"confidence_level" yaml:"confidence_level"` // Confidence level of bootstrap intervals, between 0 and 1
+	Resamples       int             `json:"resamples" yaml:"resamples"`               // Number of bootstrap resamples
+	Seed            uint64          `json:"seed,omitempty" yaml:"seed,omitempty"`     // Seed of the bootstrap random generator, so that charts are reproducible
+}
+
+// DefaultAggregationOptions returns default aggregation options
+func DefaultAggregationOptions() AggregationOptions {
+	return AggregationOptions{
+		Statistic:       StatisticMean,
+		Uncertainty:     UncertaintyNone,
+		ConfidenceLevel: DefaultConfidenceLevel,
+		Resamples:       DefaultBootstrapResamples,
+		Seed:            0,
+	}
+}
+
+// withDefaults returns the aggregation options with zero values replaced by defaults
+func (o AggregationOptions) withDefaults() AggregationOptions {
+	if o.Statistic == "" {
+		o.Statistic = StatisticMean
+	}
+	if o.Uncertainty == "" {
+		o.Uncertainty = UncertaintyNone
+	}
+	if o.ConfidenceLevel == 0 {
+		o.ConfidenceLevel = DefaultConfidenceLevel
+	}
+	if o.Resamples == 0 {
+		o.Resamples = DefaultBootstrapResamples
+	}
+	return o
+}
+
+// validate returns an error if the aggregation options are invalid
+func (o AggregationOptions) validate() error {
+	if _, err := o.Statistic.function(); err != nil {
+		return err
+	}
+	switch o.Uncertainty {
+	case UncertaintyNone, UncertaintyStddev, UncertaintyIQR, UncertaintyBootstrapCI:
+	default:
+		return fmt.Errorf("unknown uncertainty: %s", o.Uncertainty)
+	}
+	if o.ConfidenceLevel <= 0 || o.ConfidenceLevel >= 1 {
+		return fmt.Errorf("confidence level must be between 0 and 1, got %g", o.ConfidenceLevel)
+	}
+	if o.Resamples < 1 {
+		return fmt.Errorf("resamples must be positive, got %d", o.Resamples)
+	}
+	return nil
+}
+
+// function returns the function that computes the statistic from sorted samples
+func (s Statistic) function() (func(sorted []float64) float64, error) {
+	switch s {
+	case StatisticMean:
+		return mean, nil
+	case StatisticMedian:
+		return func(sorted []float64) float64 { return percentile(sorted, 50) }, nil
+	case StatisticMin:
+		return func(sorted []float64) float64 { return sorted[0] }, nil
+	case StatisticMax:
+		return func(sorted []float64) float64 { return sorted[len(sorted)-1] }, nil
+	}
+	if rest, ok := strings.CutPrefix(string(s), "p"); ok {
+		p, err := strconv.ParseFloat(rest, 64)
+		if err == nil && p >= 0 && p <= 100 {
+			return func(sorted []float64) float64 { return percentile(sorted, p) }, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown statistic: %s", s)
+}
+
+// aggregateSamples computes the plotted value, and the uncertainty range if one is
+// requested, of every axis with samples
+func (s *Series) aggregateSamples() error {
+	if len(s.Samples) == 0 {
+		return nil
+	}
+	opts := s.Aggregation.withDefaults()
+	if err := opts.validate(); err != nil {
+		return &ValidationError{
+			Field:   "series.aggregation",
+			Message: fmt.Sprintf("series %s: %v", s.Name, err),
+		}
+	}
+	statistic, _ := opts.Statistic.function()
+	// Axes are aggregated in sorted order so that bootstrap resampling is reproducible
+	keys := make([]string, 0, len(s.Samples))
+	for key := range s.Samples {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	for _, key := range keys {
+		samples := s.Samples[key]
+		if len(samples) == 0 {
+			return &ValidationError{
+				Field:   "series.samples",
+				Message: fmt.Sprintf("series %s has no samples for axis %s", s.Name, key),
+			}
+		}
+		if slices.ContainsFunc(samples, func(v float64) bool { return math.IsNaN(v) || math.IsInf(v, 0) }) {
+			return &ValidationError{
+				Field:   "series.samples",
+				Message: fmt.Sprintf("series %s has a non-finite sample for axis %s", s.Name, key),
+			}
+		}
+		sorted := slices.Sorted(slices.Values(samples))
+		value := statistic(sorted)
+		s.values[key] = value
+		var r ValueRange
+		switch opts.Uncertainty {
+		case UncertaintyStddev:
+			sd := stddev(sorted)
+			r = ValueRange{Error: &sd}
+		case UncertaintyIQR:
+			low, high := percentile(sorted, 25), percentile(sorted, 75)
+			r = ValueRange{Low: &low, High: &high}
+		case UncertaintyBootstrapCI:
+			low, high := bootstrapCI(sorted, statistic, opts.ConfidenceLevel, opts.Resamples, rng)
+			r = ValueRange{Low: &low, High: &high}
+		default:
+			continue
+		}
+		s.ranges[key] = r
+	}
+	return nil
+}
+
+// mean returns the arithmetic mean of the samples
+func mean(samples []float64) float64 {
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+	return sum / float64(len(samples))
+}
+
+// stddev returns the sample standard deviation, which is 0 for a single sample
+func stddev(samples []float64) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	m := mean(samples)
+	sum := 0.0
+	for _, v := range samples {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(samples)-1))
+}
+
+// percentile returns the p-th percentile (0 to 100) of sorted samples, interpolating
+// linearly between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return lerp(sorted[lo], sorted[hi], pos-float64(lo))
+}
+
+// bootstrapCI returns the percentile bootstrap confidence interval of a statistic of
+// sorted samples
+func bootstrapCI(sorted []float64, statistic func([]float64) float64, level float64, resamples int, rng *rand.Rand) (float64, float64) {
+	estimates := make([]float64, resamples)
+	resample := make([]float64, len(sorted))
+	for i := range estimates {
+		for j := range resample {
+			resample[j] = sorted[rng.IntN(len(sorted))]
+		}
+		slices.Sort(resample)
+		estimates[i] = statistic(resample)
+	}
+	slices.Sort(estimates)
+	alpha := (1 - level) / 2
+	return percentile(estimates, alpha*100), percentile(estimates, (1-alpha)*100)
+}
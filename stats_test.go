@@ -0,0 +1,196 @@
+package spider
+
+import (
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{"single sample", []float64{7}, 90, 7},
+		{"minimum", []float64{1, 2, 3, 4}, 0, 1},
+		{"maximum", []float64{1, 2, 3, 4}, 100, 4},
+		{"exact rank", []float64{1, 2, 3, 4, 5}, 50, 3},
+		{"interpolated median", []float64{1, 2, 3, 4}, 50, 2.5},
+		{"interpolated quartile", []float64{1, 2, 3, 4}, 25, 1.75},
+		{"fractional percentile", []float64{0, 10}, 99.9, 9.99},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("percentile(%v, %g) = %g, want %g", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStddev(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []float64
+		want    float64
+	}{
+		{"single sample", []float64{5}, 0},
+		{"equal samples", []float64{3, 3, 3}, 0},
+		{"two samples", []float64{1, 3}, math.Sqrt2},
+		{"sample deviation", []float64{2, 4, 4, 4, 5, 5, 7, 9}, math.Sqrt(32.0 / 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stddev(tt.samples); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("stddev(%v) = %g, want %g", tt.samples, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBootstrapCI(t *testing.T) {
+	tests := []struct {
+		name      string
+		sorted    []float64
+		statistic Statistic
+		level     float64
+	}{
+		{"mean", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, StatisticMean, 0.95},
+		{"median", []float64{1, 2, 2, 3, 5, 8, 13}, StatisticMedian, 0.9},
+		{"equal samples", []float64{4, 4, 4}, StatisticMean, 0.95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statistic, err := tt.statistic.function()
+			if err != nil {
+				t.Fatal(err)
+			}
+			low, high := bootstrapCI(tt.sorted, statistic, tt.level, 500, rand.New(rand.NewPCG(1, 1)))
+			value := statistic(tt.sorted)
+			if low > value || value > high {
+				t.Errorf("interval %g to %g does not contain the statistic %g", low, high, value)
+			}
+			if low < tt.sorted[0] || high > tt.sorted[len(tt.sorted)-1] {
+				t.Errorf("interval %g to %g exceeds the samples", low, high)
+			}
+			// The same seed gives the same interval
+			low2, high2 := bootstrapCI(tt.sorted, statistic, tt.level, 500, rand.New(rand.NewPCG(1, 1)))
+			if low != low2 || high != high2 {
+				t.Errorf("interval %g to %g is not reproducible, got %g to %g", low, high, low2, high2)
+			}
+		})
+	}
+}
+
+func TestStatisticFunction(t *testing.T) {
+	sorted := []float64{0, 10, 20, 30, 40}
+	tests := []struct {
+		statistic Statistic
+		want      float64
+		wantErr   bool
+	}{
+		{StatisticMean, 20, false},
+		{StatisticMedian, 20, false},
+		{StatisticMin, 0, false},
+		{StatisticMax, 40, false},
+		{"p0", 0, false},
+		{"p90", 36, false},
+		{"p99.9", 39.96, false},
+		{"p100", 40, false},
+		{"p101", 0, true},
+		{"p-1", 0, true},
+		{"pfoo", 0, true},
+		{"p", 0, true},
+		{"average", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.statistic), func(t *testing.T) {
+			f, err := tt.statistic.function()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("%s: expected an error", tt.statistic)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.statistic, err)
+			}
+			if got := f(sorted); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("%s = %g, want %g", tt.statistic, got, tt.want)
+			}
+		})
+	}
+}
+
+var samplesConfig = seriesConfig(`
+    - name: s
+      data: {b: 5, c: 5}
+      samples: {a: [1, 2, 3]}
+      aggregation: {statistic: median, uncertainty: iqr}
+`)
+
+func TestSamplesRedraw(t *testing.T) {
+	c, err := NewChartFromYAML([]byte(samplesConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drawChart(t, c)
+	s := &c.Data.Series[0]
+	if got, _ := s.value("a"); got != 2 {
+		t.Errorf("plotted a = %g, want 2", got)
+	}
+	if _, ok := s.Data["a"]; ok {
+		t.Errorf("the aggregated value was written into the data: %v", s.Data)
+	}
+	if len(s.Ranges) != 0 {
+		t.Errorf("the aggregated range was written into the ranges: %v", s.Ranges)
+	}
+	s.Samples["a"] = []float64{8}
+	drawChart(t, c)
+	if got, _ := s.value("a"); got != 8 {
+		t.Errorf("plotted a after changing the samples = %g, want 8", got)
+	}
+	if low, high := s.ranges["a"].Bounds(8); low != 8 || high != 8 {
+		t.Errorf("range after changing the samples = %g to %g, want 8 to 8", low, high)
+	}
+}
+
+func TestSamplesRoundTrip(t *testing.T) {
+	c, err := NewChartFromYAML([]byte(samplesConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drawChart(t, c)
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewChartFromYAML(data); err != nil {
+		t.Errorf("loading a marshalled chart failed: %v", err)
+	}
+}
+
+func TestSamplesConflicts(t *testing.T) {
+	tests := []struct {
+		name   string
+		series string
+		want   string
+	}{
+		{"data", "{name: s, data: {a: 1, b: 5, c: 5}, samples: {a: [1, 2]}}", "both data and samples"},
+		{"range", "{name: s, data: {b: 5, c: 5}, ranges: {a: {error: 1}}, samples: {a: [1, 2]}}", "both a range and samples"},
+		{"empty", "{name: s, data: {b: 5, c: 5}, samples: {a: []}}", "no samples"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChartFromYAML([]byte(seriesConfig("    - " + tt.series + "\n")))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
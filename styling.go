@@ -1,7 +1,9 @@
 package spider
 
 import (
+	"fmt"
 	"image/color"
+	"math"
 	"strings"
 
 	"github.com/tdewolff/canvas"
@@ -25,6 +27,12 @@ var DefaultPointMarkers = []PointShape{
 	PointShapeSquare,
 	PointShapeTriangle,
 	PointShapeDiamond,
+	PointShapeStar,
+	PointShapeTriangleDown,
+	PointShapeHexagon,
+	PointShapePlus,
+	PointShapePentagon,
+	PointShapeCross,
 }
 
 // ToCanvasColor converts a Color string to a color.Color
@@ -45,23 +53,51 @@ func (c Color) ToCanvasColor() color.Color {
 	return canvas.Transparent
 }
 
+// ToCanvasColorWithOpacity converts a Color string to a color.Color with its own
+// opacity multiplied by the given opacity
 func (c Color) ToCanvasColorWithOpacity(opacity float64) color.Color {
-	col := c.ToCanvasColor()
-	r, g, b, _ := col.RGBA()
-	// RGBA() returns uint32 values in range 0-65535, convert to uint8 (0-255)
-	// Divide by 257 for accurate conversion (65535/255 = 257)
+	// Convert to non-premultiplied alpha so that the color channels keep their values
+	n := color.NRGBAModel.Convert(c.ToCanvasColor()).(color.NRGBA)
 	// Clamp opacity to valid range [0, 1]
 	if opacity < 0 {
 		opacity = 0
 	} else if opacity > 1 {
 		opacity = 1
 	}
-	return color.RGBA{
-		R: uint8(r / 257),
-		G: uint8(g / 257),
-		B: uint8(b / 257),
-		A: uint8(opacity * 255),
+	n.A = uint8(math.Round(float64(n.A) * opacity))
+	return n
+}
+
+// dimmed returns the color mixed towards gray by the desaturation fraction, with its
+// opacity multiplied by the given opacity. An unset color stays unset so that its
+// fallback still applies.
+func (c Color) dimmed(desaturation float64, opacity float64) Color {
+	if c == "" {
+		return c
 	}
+	n := color.NRGBAModel.Convert(c.ToCanvasColor()).(color.NRGBA)
+	gray := 0.299*float64(n.R) + 0.587*float64(n.G) + 0.114*float64(n.B)
+	mix := func(v uint8) uint8 {
+		return uint8(math.Round(lerp(float64(v), gray, desaturation)))
+	}
+	alpha := uint8(math.Round(float64(n.A) * math.Max(0, math.Min(1, opacity))))
+	return Color(fmt.Sprintf("#%02x%02x%02x%02x", mix(n.R), mix(n.G), mix(n.B), alpha))
+}
+
+// dimmed returns the series options with all colors mixed towards gray by the
+// desaturation fraction and all opacities multiplied by the given opacity
+func (o SeriesOptions) dimmed(desaturation float64, opacity float64) SeriesOptions {
+	o.LineColor = o.LineColor.dimmed(desaturation, opacity)
+	o.FillColor = o.FillColor.dimmed(desaturation, 1)
+	o.FillOpacity *= opacity
+	o.GradientCenterColor = o.GradientCenterColor.dimmed(desaturation, 1)
+	o.GradientCenterOpacity *= opacity
+	o.PointStrokeColor = o.PointStrokeColor.dimmed(desaturation, opacity)
+	o.PointFillColor = o.PointFillColor.dimmed(desaturation, 1)
+	o.PointFillOpacity *= opacity
+	o.RangeColor = o.RangeColor.dimmed(desaturation, 1)
+	o.RangeOpacity *= opacity
+	return o
 }
 
 // Color utility functions
@@ -94,7 +130,8 @@ func parseHexColor(hex string) color.Color {
 		if _, err := parseUint8(hex[7:9], &a); err != nil {
 			return canvas.Black
 		}
-		return color.RGBA{R: r, G: g, B: b, A: a}
+		// Like CSS, the color channels are not premultiplied by the alpha
+		return color.NRGBA{R: r, G: g, B: b, A: a}
 	}
 	return canvas.Black
 }
@@ -279,3 +316,43 @@ func parseNamedColor(name string) *color.Color {
 	}
 	return nil
 }
+
+// dashPattern returns the dash pattern in millimeters of a line style for a line of
+// the given width. Dashes and gaps scale with the width so that thick lines keep
+// their look.
+func (s LineStyle) dashPattern(width float64) []float64 {
+	switch s {
+	case LineStyleDashed:
+		return []float64{6 * width, 3 * width}
+	case LineStyleDotted:
+		return []float64{width, 2 * width}
+	case LineStyleDashDot:
+		return []float64{6 * width, 2 * width, width, 2 * width}
+	default:
+		return nil
+	}
+}
+
+// capper returns the canvas capper of a line cap
+func (c LineCap) capper() canvas.Capper {
+	switch c {
+	case LineCapRound:
+		return canvas.RoundCap
+	case LineCapSquare:
+		return canvas.SquareCap
+	default:
+		return canvas.ButtCap
+	}
+}
+
+// joiner returns the canvas joiner of a line join
+func (j LineJoin) joiner() canvas.Joiner {
+	switch j {
+	case LineJoinRound:
+		return canvas.RoundJoin
+	case LineJoinBevel:
+		return canvas.BevelJoin
+	default:
+		return canvas.MiterJoin
+	}
+}
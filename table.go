@@ -0,0 +1,212 @@
+package spider
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+)
+
+// tableMissingValue is the text of a table cell without a value
+const tableMissingValue = "–"
+
+// TableOptions represents options for the table of series values drawn below or
+// beside the plot
+type TableOptions struct {
+	Placement     TablePlacement `json:"placement" yaml:"placement"`           // Where the table is placed (none, bottom or right)
+	Style         Font           `json:"style" yaml:"style"`                   // Cell text style
+	HeaderStyle   Font           `json:"header_style" yaml:"header_style"`     // Header text style
+	CellPadding   float64        `json:"cell_padding" yaml:"cell_padding"`     // Space around the text of each cell in millimeters
+	SwatchSize    float64        `json:"swatch_size" yaml:"swatch_size"`       // Size of the series color swatches in millimeters
+	LineColor     Color          `json:"line_color" yaml:"line_color"`         // Color of the rules above and below the table and below the header
+	LineThickness float64        `json:"line_thickness" yaml:"line_thickness"` // Thickness of the rules in millimeters
+}
+
+// DefaultTableOptions returns default table options
+func DefaultTableOptions() TableOptions {
+	return TableOptions{
+		Placement: TablePlacementNone,
+		Style: Font{
+			Size:  DefaultTableFontSize,
+			Color: Color("#000000"),
+		},
+		HeaderStyle: Font{
+			Size:  DefaultTableFontSize,
+			Color: Color("#000000"),
+		},
+		CellPadding:   DefaultTableCellPadding,
+		SwatchSize:    DefaultTableSwatchSize,
+		LineColor:     Color("#000000"),
+		LineThickness: DefaultTableLineThickness,
+	}
+}
+
+// validateTable checks the table options
+func (c *Chart) validateTable() error {
+	table := c.Options.Table
+	switch table.Placement {
+	case TablePlacementNone, TablePlacementBottom, TablePlacementRight:
+	default:
+		return &ValidationError{
+			Field:   "options.table.placement",
+			Message: fmt.Sprintf("unknown table placement: %s", table.Placement),
+		}
+	}
+	if table.CellPadding < 0 {
+		return &ValidationError{
+			Field:   "options.table.cell_padding",
+			Message: "cell_padding must not be negative",
+		}
+	}
+	if table.SwatchSize < 0 {
+		return &ValidationError{
+			Field:   "options.table.swatch_size",
+			Message: "swatch_size must not be negative",
+		}
+	}
+	if table.LineThickness < 0 {
+		return &ValidationError{
+			Field:   "options.table.line_thickness",
+			Message: "line_thickness must not be negative",
+		}
+	}
+	return nil
+}
+
+// seriesTable is the table of series values with its cells formatted and measured
+type seriesTable struct {
+	header       []string   // Column headers: an empty series column followed by the axis labels
+	rows         [][]string // Cells of each row: the series name followed by its values
+	series       []int      // Index of the series of each row
+	widths       []float64  // Width of each column including the cell padding in millimeters
+	headerHeight float64    // Height of the header row in millimeters
+	rowHeight    float64    // Height of each series row in millimeters
+}
+
+// hasTable returns true if the table is shown and lists at least one series
+func (c *Chart) hasTable() bool {
+	return c.Options.Table.Placement != TablePlacementNone && len(c.seriesTable().rows) > 0
+}
+
+// seriesTable returns the table of the values of the visible series, one row per
+// series and one column per axis. The values are formatted like the tick labels of
+// their axis.
+func (c *Chart) seriesTable() seriesTable {
+	opts := c.Options.Table
+	headerFace, cellFace := c.fonts["table_header"], c.fonts["table_cell"]
+	pad := opts.CellPadding
+	t := seriesTable{
+		header:       []string{""},
+		headerHeight: headerFace.LineHeight() + 2*pad,
+		rowHeight:    math.Max(cellFace.LineHeight(), opts.SwatchSize) + 2*pad,
+	}
+	for j := range c.Data.Axes {
+		t.header = append(t.header, tableHeader(&c.Data.Axes[j]))
+	}
+	for i := range c.Data.Series {
+		series := &c.Data.Series[i]
+		if !series.IsVisible() {
+			continue
+		}
+		cells := []string{series.Name}
+		for j := range c.Data.Axes {
+			axis := &c.Data.Axes[j]
+			text := tableMissingValue
+			if value, ok := series.value(axis.Name); ok {
+				text = c.axisOptions(axis).TickLabelFormat.FormatValue(value)
+			}
+			cells = append(cells, text)
+		}
+		t.rows = append(t.rows, cells)
+		t.series = append(t.series, i)
+	}
+	t.widths = make([]float64, len(t.header))
+	for k, text := range t.header {
+		t.widths[k] = headerFace.TextWidth(text) + 2*pad
+	}
+	for _, cells := range t.rows {
+		for k, text := range cells {
+			t.widths[k] = math.Max(t.widths[k], cellFace.TextWidth(text)+2*pad)
+		}
+	}
+	// The series names follow their color swatch
+	t.widths[0] += opts.SwatchSize + pad
+	return t
+}
+
+// tableHeader returns the column header of an axis: its label on one line, followed
+// by its unit
+func tableHeader(axis *Axis) string {
+	header := strings.Join(strings.Fields(axis.GetLabel()), " ")
+	if axis.Unit != "" {
+		header += " (" + axis.Unit + ")"
+	}
+	return header
+}
+
+// size returns the width and height of the table
+func (t *seriesTable) size() (float64, float64) {
+	width := 0.0
+	for _, w := range t.widths {
+		width += w
+	}
+	return width, t.headerHeight + float64(len(t.rows))*t.rowHeight
+}
+
+// drawTable draws the table of series values in the table area, with rules above and
+// below it and below the header
+func (c *Chart) drawTable(ctx *canvas.Context) {
+	if !c.hasTable() {
+		return
+	}
+	opts := c.Options.Table
+	t := c.seriesTable()
+	rect := c.tableRect
+	ctx.Push()
+	ctx.SetFillColor(canvas.Transparent)
+	ctx.SetStrokeColor(opts.LineColor.ToCanvasColor())
+	ctx.SetStrokeWidth(opts.LineThickness)
+	for _, y := range []float64{rect.Y1, rect.Y1 - t.headerHeight, rect.Y0} {
+		ctx.MoveTo(rect.X0, y)
+		ctx.LineTo(rect.X1, y)
+		ctx.Stroke()
+	}
+	ctx.Pop()
+
+	c.drawTableRow(ctx, c.fonts["table_header"], t.header, t.widths, rect.Y1, t.headerHeight)
+	top := rect.Y1 - t.headerHeight
+	for k, cells := range t.rows {
+		// The swatch is filled with the line color of the series, dimmed like its plot
+		seriesOpts := c.seriesOptions(t.series[k])
+		size := opts.SwatchSize
+		x := rect.X0 + opts.CellPadding
+		y := top - (t.rowHeight+size)/2
+		ctx.Push()
+		ctx.SetFillColor(seriesOpts.LineColor.ToCanvasColor())
+		ctx.SetStrokeColor(canvas.Transparent)
+		ctx.DrawPath(x, y, canvas.Rectangle(size, size))
+		ctx.Pop()
+		c.drawTableRow(ctx, c.fonts["table_cell"], cells, t.widths, top, t.rowHeight)
+		top -= t.rowHeight
+	}
+}
+
+// drawTableRow draws the cells of a table row with its top at y = top. The first
+// cell is aligned left after the swatch, and the values are aligned right so that
+// their digits line up.
+func (c *Chart) drawTableRow(ctx *canvas.Context, face *canvas.FontFace, cells []string, widths []float64, top, height float64) {
+	pad := c.Options.Table.CellPadding
+	metrics := face.Metrics()
+	// Baseline of text centered vertically in the row
+	baseline := top - height/2 - (metrics.Ascent-metrics.Descent)/2
+	x := c.tableRect.X0
+	for k, text := range cells {
+		if k == 0 {
+			ctx.DrawText(x+c.Options.Table.SwatchSize+2*pad, baseline, canvas.NewTextLine(face, text, canvas.Left))
+		} else {
+			ctx.DrawText(x+widths[k]-pad, baseline, canvas.NewTextLine(face, text, canvas.Right))
+		}
+		x += widths[k]
+	}
+}
@@ -14,6 +14,54 @@ const (
 	ScaleTypeLog2 ScaleType = "log2"
 )
 
+// TickMode represents how major tick positions are chosen on linear axes
+type TickMode string
+
+const (
+	// TickModeNice places major ticks at round step sizes (1, 2 or 5 times a power of ten)
+	TickModeNice TickMode = "nice"
+
+	// TickModeFixed divides the axis into a fixed number of equal intervals
+	TickModeFixed TickMode = "fixed"
+)
+
+// AxisLabelMode represents how axis names are oriented at the end of their axis
+type AxisLabelMode string
+
+const (
+	// AxisLabelTangential draws axis names across the end of their axis, turned to stay upright
+	AxisLabelTangential AxisLabelMode = "tangential"
+
+	// AxisLabelRadial draws axis names along their axis, turned to read from left to right
+	AxisLabelRadial AxisLabelMode = "radial"
+
+	// AxisLabelHorizontal draws axis names horizontally next to the end of their axis
+	AxisLabelHorizontal AxisLabelMode = "horizontal"
+)
+
+// FormatMode represents how numeric values are converted to labels
+type FormatMode string
+
+const (
+	// FormatModeAuto abbreviates large values with k, M, G, T and P suffixes
+	FormatModeAuto FormatMode = "auto"
+
+	// FormatModePlain prints values without any scaling or suffix
+	FormatModePlain FormatMode = "plain"
+
+	// FormatModeSI scales values with SI prefixes, from pico (p) to peta (P)
+	FormatModeSI FormatMode = "si"
+
+	// FormatModeIEC scales byte counts with binary prefixes (KiB, MiB, GiB, ...)
+	FormatModeIEC FormatMode = "iec"
+
+	// FormatModePercent formats ratios as percentages (0.25 becomes 25%)
+	FormatModePercent FormatMode = "percent"
+
+	// FormatModeDuration formats durations with ns, µs, ms or s units
+	FormatModeDuration FormatMode = "duration"
+)
+
 // ConnectType represents how the plot shape connects points
 type ConnectType string
 
@@ -25,6 +73,17 @@ const (
 	ConnectTypePolygon ConnectType = "polygon"
 )
 
+// CurveType represents how the points of a series are connected
+type CurveType string
+
+const (
+	// CurveLinear connects the points with straight lines
+	CurveLinear CurveType = "linear"
+
+	// CurveSmooth connects the points with a smooth closed curve
+	CurveSmooth CurveType = "smooth"
+)
+
 // PointShape represents the shape of data points
 type PointShape string
 
@@ -41,10 +100,222 @@ const (
 	// PointShapeDiamond represents a diamond-shaped point
 	PointShapeDiamond PointShape = "diamond"
 
+	// PointShapeTriangleDown represents a triangular point pointing down
+	PointShapeTriangleDown PointShape = "triangle_down"
+
+	// PointShapePentagon represents a pentagonal point
+	PointShapePentagon PointShape = "pentagon"
+
+	// PointShapeHexagon represents a hexagonal point
+	PointShapeHexagon PointShape = "hexagon"
+
+	// PointShapeStar represents a five-pointed star
+	PointShapeStar PointShape = "star"
+
+	// PointShapePlus represents a plus sign
+	PointShapePlus PointShape = "plus"
+
+	// PointShapeCross represents a diagonal cross
+	PointShapeCross PointShape = "cross"
+
+	// PointShapeCustom represents a point drawn from SVG path data
+	PointShapeCustom PointShape = "custom"
+
 	// PointShapeNone represents no point (hidden)
 	PointShapeNone PointShape = "none"
 )
 
+// Statistic represents how the samples of a series value are reduced to the plotted
+// value. Besides the constants below, percentiles are written as "p" followed by a
+// number from 0 to 100, e.g. "p90" or "p99.9".
+type Statistic string
+
+const (
+	// StatisticMean represents the arithmetic mean
+	StatisticMean Statistic = "mean"
+
+	// StatisticMedian represents the median
+	StatisticMedian Statistic = "median"
+
+	// StatisticMin represents the smallest sample
+	StatisticMin Statistic = "min"
+
+	// StatisticMax represents the largest sample
+	StatisticMax Statistic = "max"
+)
+
+// UncertaintyType represents how an uncertainty range is derived from samples
+type UncertaintyType string
+
+const (
+	// UncertaintyNone derives no uncertainty range
+	UncertaintyNone UncertaintyType = "none"
+
+	// UncertaintyStddev spans one sample standard deviation around the value
+	UncertaintyStddev UncertaintyType = "stddev"
+
+	// UncertaintyIQR spans the interquartile range, from the 25th to the 75th percentile
+	UncertaintyIQR UncertaintyType = "iqr"
+
+	// UncertaintyBootstrapCI spans the bootstrap confidence interval of the statistic
+	UncertaintyBootstrapCI UncertaintyType = "bootstrap_ci"
+)
+
+// FacetMode represents what each panel of a small multiples chart shows
+type FacetMode string
+
+const (
+	// FacetNone draws all series in a single plot
+	FacetNone FacetMode = "none"
+
+	// FacetSeries draws one panel per series
+	FacetSeries FacetMode = "series"
+
+	// FacetGroup draws one panel per series group
+	FacetGroup FacetMode = "group"
+)
+
+// FillStyle represents how the area of a series is filled
+type FillStyle string
+
+const (
+	// FillStyleSolid fills the area with a flat color
+	FillStyleSolid FillStyle = "solid"
+
+	// FillStyleHatch fills the area with a hatch pattern
+	FillStyleHatch FillStyle = "hatch"
+
+	// FillStyleRadialGradient fills the area with a gradient from the center outwards
+	FillStyleRadialGradient FillStyle = "radial_gradient"
+)
+
+// HatchPattern represents the pattern of hatch fills
+type HatchPattern string
+
+const (
+	// HatchPatternDiagonal represents diagonal lines
+	HatchPatternDiagonal HatchPattern = "diagonal"
+
+	// HatchPatternCross represents crossed diagonal lines
+	HatchPatternCross HatchPattern = "cross"
+
+	// HatchPatternDots represents a grid of dots
+	HatchPatternDots HatchPattern = "dots"
+)
+
+// LineStyle represents the dash pattern of a series line
+type LineStyle string
+
+const (
+	// LineStyleSolid represents a solid line
+	LineStyleSolid LineStyle = "solid"
+
+	// LineStyleDashed represents a dashed line
+	LineStyleDashed LineStyle = "dashed"
+
+	// LineStyleDotted represents a dotted line
+	LineStyleDotted LineStyle = "dotted"
+
+	// LineStyleDashDot represents a line of alternating dashes and dots
+	LineStyleDashDot LineStyle = "dash_dot"
+)
+
+// LineCap represents the shape of the ends of a line or dash
+type LineCap string
+
+const (
+	// LineCapButt ends lines flat at their end points
+	LineCapButt LineCap = "butt"
+
+	// LineCapRound ends lines with a half circle
+	LineCapRound LineCap = "round"
+
+	// LineCapSquare ends lines with a half square past their end points
+	LineCapSquare LineCap = "square"
+)
+
+// LineJoin represents the shape of the corners where line segments meet
+type LineJoin string
+
+const (
+	// LineJoinMiter joins segments with a sharp corner
+	LineJoinMiter LineJoin = "miter"
+
+	// LineJoinRound joins segments with a rounded corner
+	LineJoinRound LineJoin = "round"
+
+	// LineJoinBevel joins segments with a flattened corner
+	LineJoinBevel LineJoin = "bevel"
+)
+
+// RangeStyle represents how the uncertainty ranges of a series are drawn
+type RangeStyle string
+
+const (
+	// RangeStyleBand shades the area between the low and high polygons
+	RangeStyleBand RangeStyle = "band"
+
+	// RangeStyleErrorBars draws radial error bars along each axis
+	RangeStyleErrorBars RangeStyle = "error_bars"
+
+	// RangeStyleNone hides the uncertainty ranges
+	RangeStyleNone RangeStyle = "none"
+)
+
+// MissingValueMode represents how series without a value for an axis are handled
+type MissingValueMode string
+
+const (
+	// MissingValueError rejects series with missing values during validation
+	MissingValueError MissingValueMode = "error"
+
+	// MissingValueGap breaks the series outline at axes without a value
+	MissingValueGap MissingValueMode = "gap"
+
+	// MissingValueInterpolate connects the neighbours of axes without a value
+	MissingValueInterpolate MissingValueMode = "interpolate"
+
+	// MissingValueCenter draws missing values at the center with a hollow marker
+	MissingValueCenter MissingValueMode = "center"
+)
+
+// ValueLabelMode represents which series values get a label
+type ValueLabelMode string
+
+const (
+	// ValueLabelNone draws no value labels
+	ValueLabelNone ValueLabelMode = "none"
+
+	// ValueLabelAll labels every value
+	ValueLabelAll ValueLabelMode = "all"
+
+	// ValueLabelMax labels the largest value on each axis
+	ValueLabelMax ValueLabelMode = "max"
+
+	// ValueLabelMin labels the smallest value on each axis
+	ValueLabelMin ValueLabelMode = "min"
+
+	// ValueLabelExtremes labels the largest and smallest value on each axis
+	ValueLabelExtremes ValueLabelMode = "extremes"
+
+	// ValueLabelHighlighted labels every value of the highlighted series
+	ValueLabelHighlighted ValueLabelMode = "highlighted"
+)
+
+// DeltaLabelMode represents where the percent deltas to the reference series are shown
+type DeltaLabelMode string
+
+const (
+	// DeltaLabelNone hides the deltas
+	DeltaLabelNone DeltaLabelMode = "none"
+
+	// DeltaLabelLegend shows the mean improvement of each series in the legend
+	DeltaLabelLegend DeltaLabelMode = "legend"
+
+	// DeltaLabelPoints shows the delta of each value next to its point
+	DeltaLabelPoints DeltaLabelMode = "points"
+)
+
 // LegendPlacement represents where the legend should be placed
 type LegendPlacement string
 
@@ -61,10 +332,50 @@ const (
 	// LegendPlacementRight places the legend on the right
 	LegendPlacementRight LegendPlacement = "right"
 
+	// LegendPlacementTopLeft places the legend in the top left corner, over the plot area
+	LegendPlacementTopLeft LegendPlacement = "top_left"
+
+	// LegendPlacementTopRight places the legend in the top right corner, over the plot area
+	LegendPlacementTopRight LegendPlacement = "top_right"
+
+	// LegendPlacementBottomLeft places the legend in the bottom left corner, over the plot area
+	LegendPlacementBottomLeft LegendPlacement = "bottom_left"
+
+	// LegendPlacementBottomRight places the legend in the bottom right corner, over the plot area
+	LegendPlacementBottomRight LegendPlacement = "bottom_right"
+
 	// LegendPlacementNone disables the legend
 	LegendPlacementNone LegendPlacement = "none"
 )
 
+// LegendOrder represents the order of the legend entries
+type LegendOrder string
+
+const (
+	// LegendOrderData lists the series in data order
+	LegendOrderData LegendOrder = "data"
+
+	// LegendOrderReverse lists the series in reverse data order
+	LegendOrderReverse LegendOrder = "reverse"
+
+	// LegendOrderName lists the series sorted by name
+	LegendOrderName LegendOrder = "name"
+)
+
+// TablePlacement represents where the table of series values is placed
+type TablePlacement string
+
+const (
+	// TablePlacementNone draws no table
+	TablePlacementNone TablePlacement = "none"
+
+	// TablePlacementBottom places the table below the plot
+	TablePlacementBottom TablePlacement = "bottom"
+
+	// TablePlacementRight places the table to the right of the plot
+	TablePlacementRight TablePlacement = "right"
+)
+
 func (s ScaleType) String() string {
 	return string(s)
 }
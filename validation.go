@@ -3,6 +3,7 @@ package spider
 import (
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/tdewolff/canvas"
 )
@@ -46,10 +47,35 @@ func (c *Chart) validate() error {
 		return fmt.Errorf("failed to load legend label style font: %w", err)
 	}
 	c.fonts["legend_label"] = face
-	if len(c.fonts) != 5 {
+	face, err = c.Options.ValueLabels.Style.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+	if err != nil || face == nil {
+		return fmt.Errorf("failed to load value label style font: %w", err)
+	}
+	c.fonts["value_label"] = face
+	face, err = c.Options.Facets.PanelTitleStyle.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+	if err != nil || face == nil {
+		return fmt.Errorf("failed to load panel title style font: %w", err)
+	}
+	c.fonts["panel_title"] = face
+	face, err = c.Options.LegendOptions.TitleStyle.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+	if err != nil || face == nil {
+		return fmt.Errorf("failed to load legend title style font: %w", err)
+	}
+	c.fonts["legend_title"] = face
+	face, err = c.Options.Table.Style.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+	if err != nil || face == nil {
+		return fmt.Errorf("failed to load table style font: %w", err)
+	}
+	c.fonts["table_cell"] = face
+	face, err = c.Options.Table.HeaderStyle.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+	if err != nil || face == nil {
+		return fmt.Errorf("failed to load table header style font: %w", err)
+	}
+	c.fonts["table_header"] = face
+	if len(c.fonts) != 10 {
 		return &ValidationError{
 			Field:   "fonts",
-			Message: fmt.Sprintf("expected 5 fonts, got %d", len(c.fonts)),
+			Message: fmt.Sprintf("expected 10 fonts, got %d", len(c.fonts)),
 		}
 	}
 
@@ -95,6 +121,98 @@ func (c *Chart) validate() error {
 		axisNameMap[axis.Name] = true
 	}
 
+	// Load fonts for axes that override the label styles
+	for i := range c.Data.Axes {
+		axis := &c.Data.Axes[i]
+		if axis.Options == nil {
+			continue
+		}
+		opts := c.axisOptions(axis)
+		if axis.Options.LabelStyle != nil {
+			face, err := opts.LabelStyle.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+			if err != nil || face == nil {
+				return fmt.Errorf("failed to load axis label style font for axis %s: %w", axis.Name, err)
+			}
+			c.fonts[axisFontKey("axis_label", axis.Name)] = face
+		}
+		if axis.Options.TickLabelStyle != nil {
+			face, err := opts.TickLabelStyle.loadFontFace(c.Options.DefaultFontName, c.Options.DefaultFontPath)
+			if err != nil || face == nil {
+				return fmt.Errorf("failed to load tick label style font for axis %s: %w", axis.Name, err)
+			}
+			c.fonts[axisFontKey("tick_label", axis.Name)] = face
+		}
+	}
+
+	switch c.Options.MissingValues {
+	case MissingValueError, MissingValueGap, MissingValueInterpolate, MissingValueCenter:
+	default:
+		return &ValidationError{
+			Field:   "options.missing_values",
+			Message: fmt.Sprintf("unknown missing value mode: %s", c.Options.MissingValues),
+		}
+	}
+
+	if err := c.Options.ValueLabels.Mode.validate(); err != nil {
+		return &ValidationError{
+			Field:   "options.value_labels.mode",
+			Message: err.Error(),
+		}
+	}
+	for _, name := range c.Options.Highlight {
+		if !slices.ContainsFunc(c.Data.Series, func(s Series) bool { return s.Name == name }) {
+			return &ValidationError{
+				Field:   "options.highlight",
+				Message: "unknown series: " + name,
+			}
+		}
+	}
+
+	if c.Options.DimOpacity < 0 || c.Options.DimOpacity > 1 {
+		return &ValidationError{
+			Field:   "options.dim_opacity",
+			Message: "dim_opacity must be between 0 and 1",
+		}
+	}
+	if c.Options.DimDesaturation < 0 || c.Options.DimDesaturation > 1 {
+		return &ValidationError{
+			Field:   "options.dim_desaturation",
+			Message: "dim_desaturation must be between 0 and 1",
+		}
+	}
+	if c.Options.History.MinOpacity < 0 || c.Options.History.MinOpacity > 1 {
+		return &ValidationError{
+			Field:   "options.history.min_opacity",
+			Message: "min_opacity must be between 0 and 1",
+		}
+	}
+	if c.Options.History.ArrowSize < 0 {
+		return &ValidationError{
+			Field:   "options.history.arrow_size",
+			Message: "arrow_size must not be negative",
+		}
+	}
+	if err := c.validateFacets(); err != nil {
+		return err
+	}
+	if err := c.validateTable(); err != nil {
+		return err
+	}
+	if ref := c.Options.Reference.Series; ref != "" && c.referenceIndex() < 0 {
+		return &ValidationError{
+			Field:   "options.reference.series",
+			Message: "unknown series: " + ref,
+		}
+	}
+	switch c.Options.Reference.DeltaLabels {
+	case DeltaLabelNone, DeltaLabelLegend, DeltaLabelPoints:
+	default:
+		return &ValidationError{
+			Field:   "options.reference.delta_labels",
+			Message: fmt.Sprintf("unknown delta label mode: %s", c.Options.Reference.DeltaLabels),
+		}
+	}
+
 	// Validate series data
 	for i, series := range c.Data.Series {
 		if series.Name == "" {
@@ -103,11 +221,133 @@ func (c *Chart) validate() error {
 				Message: fmt.Sprintf("series at index %d has no name", i),
 			}
 		}
-		if err := series.ValidateData(axisNames); err != nil {
+		if err := series.validateData(axisNames, c.Options.MissingValues != MissingValueError); err != nil {
+			return fmt.Errorf("series %s: %w", series.Name, err)
+		}
+		if err := series.validateHistory(axisNames); err != nil {
 			return fmt.Errorf("series %s: %w", series.Name, err)
 		}
 	}
 
+	// Validate series options
+	for _, series := range c.Data.Series {
+		switch series.Options.FillStyle {
+		case "", FillStyleSolid, FillStyleHatch, FillStyleRadialGradient:
+		default:
+			return &ValidationError{
+				Field:   "series.options.fill_style",
+				Message: fmt.Sprintf("series %s has unknown fill style: %s", series.Name, series.Options.FillStyle),
+			}
+		}
+		switch series.Options.HatchPattern {
+		case "", HatchPatternDiagonal, HatchPatternCross, HatchPatternDots:
+		default:
+			return &ValidationError{
+				Field:   "series.options.hatch_pattern",
+				Message: fmt.Sprintf("series %s has unknown hatch pattern: %s", series.Name, series.Options.HatchPattern),
+			}
+		}
+		if series.Options.HatchSpacing < 0 || series.Options.HatchThickness < 0 {
+			return &ValidationError{
+				Field:   "series.options.hatch_spacing",
+				Message: fmt.Sprintf("series %s has negative hatch spacing or thickness", series.Name),
+			}
+		}
+		switch series.Options.Curve {
+		case "", CurveLinear, CurveSmooth:
+		default:
+			return &ValidationError{
+				Field:   "series.options.curve",
+				Message: fmt.Sprintf("series %s has unknown curve: %s", series.Name, series.Options.Curve),
+			}
+		}
+		if series.Options.Tension < 0 || series.Options.Tension > 1 {
+			return &ValidationError{
+				Field:   "series.options.tension",
+				Message: fmt.Sprintf("series %s has tension %g, must be between 0 and 1", series.Name, series.Options.Tension),
+			}
+		}
+		switch series.Options.LineStyle {
+		case "", LineStyleSolid, LineStyleDashed, LineStyleDotted, LineStyleDashDot:
+		default:
+			return &ValidationError{
+				Field:   "series.options.line_style",
+				Message: fmt.Sprintf("series %s has unknown line style: %s", series.Name, series.Options.LineStyle),
+			}
+		}
+		for _, d := range series.Options.Dashes {
+			if d < 0 {
+				return &ValidationError{
+					Field:   "series.options.dashes",
+					Message: fmt.Sprintf("series %s has negative dash length: %g", series.Name, d),
+				}
+			}
+		}
+		switch series.Options.LineCap {
+		case "", LineCapButt, LineCapRound, LineCapSquare:
+		default:
+			return &ValidationError{
+				Field:   "series.options.line_cap",
+				Message: fmt.Sprintf("series %s has unknown line cap: %s", series.Name, series.Options.LineCap),
+			}
+		}
+		switch series.Options.LineJoin {
+		case "", LineJoinMiter, LineJoinRound, LineJoinBevel:
+		default:
+			return &ValidationError{
+				Field:   "series.options.line_join",
+				Message: fmt.Sprintf("series %s has unknown line join: %s", series.Name, series.Options.LineJoin),
+			}
+		}
+		if series.Options.ValueLabels != "" {
+			if err := series.Options.ValueLabels.validate(); err != nil {
+				return &ValidationError{
+					Field:   "series.options.value_labels",
+					Message: fmt.Sprintf("series %s: %s", series.Name, err),
+				}
+			}
+		}
+		switch series.Options.PointShape {
+		case "", PointShapeCircle, PointShapeSquare, PointShapeTriangle, PointShapeDiamond, PointShapeTriangleDown,
+			PointShapePentagon, PointShapeHexagon, PointShapeStar, PointShapePlus, PointShapeCross, PointShapeNone:
+		case PointShapeCustom:
+			if series.Options.PointPath == "" {
+				return &ValidationError{
+					Field:   "series.options.point_path",
+					Message: fmt.Sprintf("series %s has a custom point shape without point_path", series.Name),
+				}
+			}
+		default:
+			return &ValidationError{
+				Field:   "series.options.point_shape",
+				Message: fmt.Sprintf("series %s has unknown point shape: %s", series.Name, series.Options.PointShape),
+			}
+		}
+		if series.Options.PointPath != "" {
+			if _, err := parseMarkerPath(series.Options.PointPath); err != nil {
+				return &ValidationError{
+					Field:   "series.options.point_path",
+					Message: fmt.Sprintf("series %s: %v", series.Name, err),
+				}
+			}
+		}
+		switch series.Options.RangeStyle {
+		case "", RangeStyleBand, RangeStyleErrorBars, RangeStyleNone:
+		default:
+			return &ValidationError{
+				Field:   "series.options.range_style",
+				Message: fmt.Sprintf("series %s has unknown range style: %s", series.Name, series.Options.RangeStyle),
+			}
+		}
+	}
+
+	// Validate axis scales against the series data
+	for _, axis := range c.Data.Axes {
+		if err := axis.validate(c.Data.Series); err != nil {
+			return err
+		}
+	}
+
 	// Validate chart options
 	if c.Options.Width <= 0 {
 		return &ValidationError{
@@ -128,6 +368,38 @@ func (c *Chart) validate() error {
 		}
 	}
 
+	switch c.Options.AxisOptions.TickMode {
+	case TickModeNice, TickModeFixed:
+	default:
+		return &ValidationError{
+			Field:   "options.axis_options.tick_mode",
+			Message: fmt.Sprintf("unknown tick mode: %s", c.Options.AxisOptions.TickMode),
+		}
+	}
+
+	switch c.Options.AxisOptions.LabelMode {
+	case AxisLabelTangential, AxisLabelRadial, AxisLabelHorizontal:
+	default:
+		return &ValidationError{
+			Field:   "options.axis_options.label_mode",
+			Message: fmt.Sprintf("unknown label mode: %s", c.Options.AxisOptions.LabelMode),
+		}
+	}
+
+	if c.Options.AxisOptions.LabelMaxWidth < 0 {
+		return &ValidationError{
+			Field:   "options.axis_options.label_max_width",
+			Message: "label_max_width must not be negative",
+		}
+	}
+
+	if err := c.Options.AxisOptions.TickLabelFormat.validate(); err != nil {
+		return &ValidationError{
+			Field:   "options.axis_options.tick_label_format",
+			Message: err.Error(),
+		}
+	}
+
 	// Validate legend options
 	if c.Options.LegendOptions.MinWidth <= 0 {
 		c.Options.LegendOptions.MinWidth = 0
@@ -147,6 +419,9 @@ func (c *Chart) validate() error {
 			Message: "min_width must be less than max_width",
 		}
 	}
+	if err := c.validateLegend(); err != nil {
+		return err
+	}
 
 	return nil
 }